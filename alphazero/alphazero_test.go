@@ -0,0 +1,126 @@
+package alphazero
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/nn"
+)
+
+func TestSearch_Run(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	net, err := nn.NewMLP(3, 3, 3, 16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		name  string
+		board [][]int
+		i, j  int
+	}{
+		{
+			name:  "win",
+			board: [][]int{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}},
+			i:     0,
+			j:     2,
+		},
+		{
+			name:  "block",
+			board: [][]int{{1, 0, 0}, {2, 2, 0}, {1, 0, 0}},
+			i:     1,
+			j:     2,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewSearch(e, net, 400, 1.5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, _ := NewPlayer(s, "search")
+			if i, j := p.Play(tc.board, 1); i != tc.i || j != tc.j {
+				t.Errorf("Player.Play() = (%v, %v), want (%v, %v)", i, j, tc.i, tc.j)
+			}
+		})
+	}
+
+	if _, err := NewSearch(e, net, 1, 1.5); err != game.ErrInvalidGameSpecs {
+		t.Errorf("NewSearch() with 1 simulation error = %v, want %v", err, game.ErrInvalidGameSpecs)
+	}
+	// two simulations visit a single move, which must be legal
+	s, _ := NewSearch(e, net, 2, 1.5)
+	p, _ := NewPlayer(s, "search")
+	board := [][]int{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	if i, j := p.Play(board, 2); !e.Legal(board, i, j) {
+		t.Errorf("Player.Play() with 2 simulations = (%v, %v), want a legal move", i, j)
+	}
+
+	// only players that record keep training samples
+	for _, record := range []bool{false, true} {
+		pl, _ := newPlayer(s, "search", 1, rand.New(rand.NewSource(1)), record)
+		pl.Play(board, 2)
+		if got := len(pl.take(0)); (got == 1) != record {
+			t.Errorf("newPlayer() with record %v took %v samples", record, got)
+		}
+	}
+}
+
+func TestPipeline_Iterate(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	dir, err := os.MkdirTemp("", "alphazero")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultConfig()
+	cfg.Simulations = 20
+	cfg.Games = 4
+	cfg.GatingGames = 2
+	cfg.GatingThreshold = 0
+	cfg.Dir = dir
+	p, err := NewPipeline(e, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := p.Iterate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Iteration != 1 || r.Samples < 4*5 || !r.Accepted {
+		t.Errorf("Pipeline.Iterate() = %+v", r)
+	}
+	for _, name := range []string{"checkpoint-0001.json", "best.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	resumed, err := NewPipeline(e, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := make([]float32, 27)
+	want, _ := p.Best().Forward(input)
+	got, _ := resumed.Best().Forward(input)
+	for k := range want {
+		if got[k] != want[k] {
+			t.Fatalf("resumed network policy = %v, want %v", got, want)
+		}
+	}
+	// the resumed pipeline continues the iterations instead of overwriting the first checkpoint
+	if r, err := resumed.Iterate(); err != nil || r.Iteration != 2 {
+		t.Errorf("resumed Pipeline.Iterate() = %+v, %v, want iteration 2", r, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "checkpoint-0002.json")); err != nil {
+		t.Error(err)
+	}
+
+	other, _ := game.NewEngine(4, 4, 3)
+	if _, err := NewPipeline(other, cfg); err != nn.ErrInvalidNetwork {
+		t.Errorf("NewPipeline() error = %v, want %v", err, nn.ErrInvalidNetwork)
+	}
+}
@@ -0,0 +1,259 @@
+package alphazero
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/mraufc/tictactoe/dataset"
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/nn"
+)
+
+// Config is the configuration of a training pipeline.
+type Config struct {
+	Hidden          int     // size of the hidden layer of new networks
+	Simulations     int     // search simulations per move, at least 2
+	CPuct           float64 // exploration weight of the search
+	Games           int     // self-play games per iteration
+	SampledMoves    int     // moves at the start of self-play and gating games that are sampled
+	Window          int     // number of iterations whose samples are trained on
+	Epochs          int     // passes over the training samples per iteration
+	BatchSize       int     // samples per gradient descent step
+	LearningRate    float32 // gradient descent learning rate
+	GatingGames     int     // games of the gating match, half of them as X
+	GatingThreshold float64 // score the trained network needs against the best network to replace it
+	Seed            int64   // seed of every random choice of the pipeline
+	Dir             string  // checkpoint directory, checkpoints are not written if empty
+}
+
+// DefaultConfig returns a configuration that trains a 3x3 network in a few minutes.
+func DefaultConfig() Config {
+	return Config{
+		Hidden:          64,
+		Simulations:     100,
+		CPuct:           1.5,
+		Games:           50,
+		SampledMoves:    2,
+		Window:          4,
+		Epochs:          4,
+		BatchSize:       32,
+		LearningRate:    0.05,
+		GatingGames:     20,
+		GatingThreshold: 0.55,
+	}
+}
+
+// Report is the result of a pipeline iteration.
+type Report struct {
+	Iteration int
+	Samples   int     // number of samples trained on
+	Loss      float32 // mean loss of the last epoch
+	Score     float64 // score of the trained network in the gating match
+	Accepted  bool    // whether the trained network replaced the best network
+}
+
+// Pipeline is an AlphaZero style training pipeline for an engine's board.
+type Pipeline struct {
+	e         *game.Engine
+	cfg       Config
+	rnd       *rand.Rand
+	best      *nn.Network
+	iteration int
+	history   [][]dataset.Sample // samples of the last Window iterations
+}
+
+// NewPipeline returns a new training pipeline. If the checkpoint directory holds a best network
+// for the engine's board, training resumes from it, and iterations continue from the highest
+// numbered checkpoint in the directory.
+func NewPipeline(e *game.Engine, cfg Config) (*Pipeline, error) {
+	if e == nil || cfg.Simulations < 2 || cfg.Games < 1 || cfg.Epochs < 1 || cfg.BatchSize < 1 ||
+		cfg.GatingGames < 1 || cfg.Window < 1 || cfg.Hidden < 1 {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	p := &Pipeline{e: e, cfg: cfg, rnd: rand.New(rand.NewSource(cfg.Seed))}
+	if cfg.Dir != "" {
+		if f, err := os.Open(filepath.Join(cfg.Dir, "best.json")); err == nil {
+			defer f.Close()
+			best, err := nn.Load(f)
+			if err != nil {
+				return nil, err
+			}
+			if best.Rows != e.Rows() || best.Columns != e.Columns() || best.Target != e.Target() {
+				return nil, nn.ErrInvalidNetwork
+			}
+			p.best = best
+		}
+		iteration, err := lastCheckpoint(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		p.iteration = iteration
+	}
+	if p.best == nil {
+		best, err := nn.NewMLP(e.Rows(), e.Columns(), e.Target(), cfg.Hidden, cfg.Seed)
+		if err != nil {
+			return nil, err
+		}
+		p.best = best
+	}
+	return p, nil
+}
+
+// Best returns the best network so far.
+func (p *Pipeline) Best() *nn.Network {
+	return p.best
+}
+
+// Iterate runs a single iteration of the pipeline: self-play with the best network, training a copy
+// of it, a gating match between the two and checkpointing.
+func (p *Pipeline) Iterate() (Report, error) {
+	p.iteration++
+	report := Report{Iteration: p.iteration}
+
+	samples, err := p.selfPlay()
+	if err != nil {
+		return report, err
+	}
+	p.history = append(p.history, samples)
+	if len(p.history) > p.cfg.Window {
+		p.history = p.history[1:]
+	}
+	var training []dataset.Sample
+	for _, s := range p.history {
+		training = append(training, s...)
+	}
+	report.Samples = len(training)
+
+	candidate := p.best.Clone()
+	for epoch := 0; epoch < p.cfg.Epochs; epoch++ {
+		p.rnd.Shuffle(len(training), func(a, b int) { training[a], training[b] = training[b], training[a] })
+		var loss float32
+		batches := 0
+		for k := 0; k < len(training); k += p.cfg.BatchSize {
+			end := k + p.cfg.BatchSize
+			if end > len(training) {
+				end = len(training)
+			}
+			loss += candidate.Train(training[k:end], p.cfg.LearningRate)
+			batches++
+		}
+		report.Loss = loss / float32(batches)
+	}
+
+	report.Score, err = p.gate(candidate)
+	if err != nil {
+		return report, err
+	}
+	if report.Score >= p.cfg.GatingThreshold {
+		p.best = candidate
+		report.Accepted = true
+	}
+	return report, p.checkpoint(candidate, report.Accepted)
+}
+
+// selfPlay plays the configured number of games of the best network against itself.
+func (p *Pipeline) selfPlay() ([]dataset.Sample, error) {
+	s, err := NewSearch(p.e, p.best, p.cfg.Simulations, p.cfg.CPuct)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := newPlayer(s, "self-play", p.cfg.SampledMoves, p.rnd, true)
+	if err != nil {
+		return nil, err
+	}
+	var samples []dataset.Sample
+	for k := 0; k < p.cfg.Games; k++ {
+		g, err := game.New(p.e, pl, pl)
+		if err != nil {
+			return nil, err
+		}
+		for g.Play() {
+		}
+		_, winner := g.Result()
+		samples = append(samples, pl.take(winner)...)
+	}
+	return samples, nil
+}
+
+// gate plays the gating match and returns the candidate's score, 1 for a win and 0.5 for a draw per game.
+func (p *Pipeline) gate(candidate *nn.Network) (float64, error) {
+	cs, err := NewSearch(p.e, candidate, p.cfg.Simulations, p.cfg.CPuct)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := NewSearch(p.e, p.best, p.cfg.Simulations, p.cfg.CPuct)
+	if err != nil {
+		return 0, err
+	}
+	score := 0.0
+	for k := 0; k < p.cfg.GatingGames; k++ {
+		c, _ := newPlayer(cs, "candidate", p.cfg.SampledMoves, p.rnd, false)
+		b, _ := newPlayer(bs, "best", p.cfg.SampledMoves, p.rnd, false)
+		side := 1 + k%2
+		var g *game.TicTacToe
+		if side == 1 {
+			g, err = game.New(p.e, c, b)
+		} else {
+			g, err = game.New(p.e, b, c)
+		}
+		if err != nil {
+			return 0, err
+		}
+		for g.Play() {
+		}
+		switch _, winner := g.Result(); winner {
+		case side:
+			score++
+		case 0:
+			score += 0.5
+		}
+	}
+	return score / float64(p.cfg.GatingGames), nil
+}
+
+// checkpoint writes the trained network of the iteration and, if it was accepted, the best network.
+func (p *Pipeline) checkpoint(candidate *nn.Network, accepted bool) error {
+	if p.cfg.Dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(p.cfg.Dir, 0755); err != nil {
+		return err
+	}
+	if err := save(filepath.Join(p.cfg.Dir, fmt.Sprintf("checkpoint-%04d.json", p.iteration)), candidate); err != nil {
+		return err
+	}
+	if accepted {
+		return save(filepath.Join(p.cfg.Dir, "best.json"), p.best)
+	}
+	return nil
+}
+
+// lastCheckpoint returns the highest iteration of the checkpoints in dir, or 0 if there are none.
+func lastCheckpoint(dir string) (int, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "checkpoint-*.json"))
+	if err != nil {
+		return 0, err
+	}
+	last := 0
+	for _, name := range names {
+		var iteration int
+		if _, err := fmt.Sscanf(filepath.Base(name), "checkpoint-%d.json", &iteration); err == nil && iteration > last {
+			last = iteration
+		}
+	}
+	return last, nil
+}
+
+func save(name string, n *nn.Network) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := n.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
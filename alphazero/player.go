@@ -0,0 +1,98 @@
+package alphazero
+
+import (
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/dataset"
+	"github.com/mraufc/tictactoe/game"
+)
+
+// Player is a player.Player that plays the most visited move of a network guided search.
+// For the first moves of a game it can sample moves in proportion to their visits instead,
+// which makes self-play and gating games varied.
+type Player struct {
+	s        *Search
+	name     string
+	sampled  int // number of moves of a game that are sampled
+	rnd      *rand.Rand
+	record   bool // whether training samples are recorded
+	samples  []dataset.Sample
+	recorded []int // side to move of each sample
+}
+
+// NewPlayer returns a new search player that always plays the most visited move.
+func NewPlayer(s *Search, name string) (*Player, error) {
+	return newPlayer(s, name, 0, nil, false)
+}
+
+// newPlayer returns a new search player that samples the first sampled moves of a game with rnd and,
+// if record is true, records a training sample for every move.
+func newPlayer(s *Search, name string, sampled int, rnd *rand.Rand, record bool) (*Player, error) {
+	if s == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Player{s: s, name: name, sampled: sampled, rnd: rnd, record: record}, nil
+}
+
+// Play searches the board and returns the chosen move.
+func (p *Player) Play(board [][]int, side int) (int, int) {
+	st, err := game.NewState(p.s.e, board)
+	if err != nil || st.Side() != side {
+		return -1, -1
+	}
+	if inProgress, _ := st.Result(); !inProgress {
+		return -1, -1
+	}
+	pi := p.s.Run(st)
+	if p.record {
+		p.samples = append(p.samples, dataset.Sample{
+			Features: dataset.Features(p.s.e, board, side),
+			Mask:     dataset.Mask(p.s.e, board),
+			Policy:   pi,
+		})
+		p.recorded = append(p.recorded, side)
+	}
+	m := 0
+	if p.rnd != nil && st.Moves() < p.sampled {
+		r := p.rnd.Float32()
+		for k, v := range pi {
+			if v > 0 {
+				m = k
+			}
+			if r -= v; r < 0 && v > 0 {
+				break
+			}
+		}
+	} else {
+		for k, v := range pi {
+			if v > pi[m] {
+				m = k
+			}
+		}
+	}
+	return m / p.s.e.Columns(), m % p.s.e.Columns()
+}
+
+// Done does nothing, outcomes of self-play games are assigned by the pipeline.
+func (p *Player) Done(winner int) {}
+
+// Name returns the player name.
+func (p *Player) Name() string {
+	return p.name
+}
+
+// take returns the samples recorded since the last call with their values set for the game's winner.
+func (p *Player) take(winner int) []dataset.Sample {
+	samples := p.samples
+	for k := range samples {
+		switch winner {
+		case 0:
+		case p.recorded[k]:
+			samples[k].Value = 1
+		default:
+			samples[k].Value = -1
+		}
+	}
+	p.samples, p.recorded = nil, nil
+	return samples
+}
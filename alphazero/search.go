@@ -0,0 +1,111 @@
+// Package alphazero is a CPU only AlphaZero style training pipeline for small boards.
+//
+// A policy and value network of the nn package guides a Monte Carlo tree search, the search plays
+// games of TicTacToe against itself to generate training samples, the network is trained on them with
+// stochastic gradient descent, and the trained network replaces the best network only if it wins a gating
+// match against it. Everything runs offline in pure Go.
+package alphazero
+
+import (
+	"math"
+
+	"github.com/mraufc/tictactoe/dataset"
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/nn"
+)
+
+// Search is a Monte Carlo tree search guided by a policy and value network.
+type Search struct {
+	e           *game.Engine
+	net         *nn.Network
+	simulations int
+	cpuct       float64
+}
+
+// NewSearch returns a new search that runs simulations simulations per move. cpuct weights the network's
+// policy against the values found by the search. At least 2 simulations are needed, the first one only
+// expands the root and visits none of its moves.
+func NewSearch(e *game.Engine, net *nn.Network, simulations int, cpuct float64) (*Search, error) {
+	if e == nil || net == nil || simulations < 2 {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	if net.Rows != e.Rows() || net.Columns != e.Columns() {
+		return nil, nn.ErrInvalidNetwork
+	}
+	return &Search{e: e, net: net, simulations: simulations, cpuct: cpuct}, nil
+}
+
+type node struct {
+	prior    float64
+	visits   int
+	value    float64 // sum of values from the point of view of the side that moved into the node
+	children map[int]*node
+}
+
+// Run searches the state and returns the visit distribution of the moves of the side to move,
+// one value per board position in row major order. The state is left unchanged.
+func (s *Search) Run(st *game.State) []float32 {
+	root := &node{}
+	for k := 0; k < s.simulations; k++ {
+		s.simulate(st, root)
+	}
+	pi := make([]float32, s.e.Rows()*s.e.Columns())
+	total := 0
+	for _, c := range root.children {
+		total += c.visits
+	}
+	for m, c := range root.children {
+		if total > 0 {
+			pi[m] = float32(c.visits) / float32(total)
+		}
+	}
+	return pi
+}
+
+// simulate runs a single simulation from st and returns its value for the side to move of st.
+func (s *Search) simulate(st *game.State, n *node) float64 {
+	if inProgress, winner := st.Result(); !inProgress {
+		if winner == 0 {
+			return 0
+		}
+		// the previous move won the game
+		return -1
+	}
+	if n.children == nil {
+		board := st.Board()
+		mask := dataset.Mask(s.e, board)
+		logits, value := s.net.Forward(dataset.Features(s.e, board, st.Side()))
+		probs := nn.Softmax(logits, mask)
+		n.children = make(map[int]*node)
+		for m, legal := range mask {
+			if legal > 0 {
+				n.children[m] = &node{prior: float64(probs[m])}
+			}
+		}
+		return float64(value)
+	}
+
+	parent := math.Sqrt(float64(n.visits) + 1)
+	best, bestScore := -1, math.Inf(-1)
+	for m := 0; m < s.e.Rows()*s.e.Columns(); m++ {
+		c, ok := n.children[m]
+		if !ok {
+			continue
+		}
+		q := 0.0
+		if c.visits > 0 {
+			q = c.value / float64(c.visits)
+		}
+		if score := q + s.cpuct*c.prior*parent/float64(1+c.visits); score > bestScore {
+			best, bestScore = m, score
+		}
+	}
+	c := n.children[best]
+	st.Do(best/s.e.Columns(), best%s.e.Columns())
+	v := -s.simulate(st, c)
+	st.Undo()
+	c.visits++
+	c.value += v
+	n.visits++
+	return v
+}
@@ -0,0 +1,141 @@
+// Package baseline provides simple seedable players to test against and to compare learning players with.
+package baseline
+
+import (
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// Random is a player.Player that plays a uniformly random legal move.
+type Random struct {
+	e   *game.Engine
+	rnd *rand.Rand
+}
+
+// NewRandom returns a new random player. Players with the same seed play the same moves.
+func NewRandom(e *game.Engine, seed int64) (*Random, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Random{e: e, rnd: rand.New(rand.NewSource(seed))}, nil
+}
+
+// Play returns a random legal move, or an illegal move if there is none.
+func (p *Random) Play(board [][]int, side int) (int, int) {
+	moves := p.e.LegalMoves(board)
+	if len(moves) == 0 {
+		return -1, -1
+	}
+	m := moves[p.rnd.Intn(len(moves))]
+	return m[0], m[1]
+}
+
+// Done does nothing.
+func (p *Random) Done(winner int) {}
+
+// Name returns the player name.
+func (p *Random) Name() string {
+	return "random"
+}
+
+// Center is a player.Player that plays a random legal move weighted by the number of lines through it,
+// which prefers the center of the board over its edges and corners.
+type Center struct {
+	e       *game.Engine
+	rnd     *rand.Rand
+	weights [][]int
+}
+
+// NewCenter returns a new center preferring player. Players with the same seed play the same moves.
+func NewCenter(e *game.Engine, seed int64) (*Center, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Center{e: e, rnd: rand.New(rand.NewSource(seed)), weights: Lines(e)}, nil
+}
+
+// Play returns a weighted random legal move, or an illegal move if there is none.
+func (p *Center) Play(board [][]int, side int) (int, int) {
+	moves := p.e.LegalMoves(board)
+	total := 0
+	for _, m := range moves {
+		total += p.weights[m[0]][m[1]]
+	}
+	if total == 0 {
+		return -1, -1
+	}
+	r := p.rnd.Intn(total)
+	for _, m := range moves {
+		if r -= p.weights[m[0]][m[1]]; r < 0 {
+			return m[0], m[1]
+		}
+	}
+	return -1, -1
+}
+
+// Done does nothing.
+func (p *Center) Done(winner int) {}
+
+// Name returns the player name.
+func (p *Center) Name() string {
+	return "center"
+}
+
+// Tactical is a player.Player that wins if it can, blocks the opponent's win if it has to and
+// plays a random legal move otherwise.
+type Tactical struct {
+	e      *game.Engine
+	random *Random
+}
+
+// NewTactical returns a new tactical player. Players with the same seed play the same moves.
+func NewTactical(e *game.Engine, seed int64) (*Tactical, error) {
+	r, err := NewRandom(e, seed)
+	if err != nil {
+		return nil, err
+	}
+	return &Tactical{e: e, random: r}, nil
+}
+
+// Play returns a winning move, a blocking move or a random legal move in that order of preference.
+func (p *Tactical) Play(board [][]int, side int) (int, int) {
+	if moves := p.e.WinningMoves(board, side); len(moves) > 0 {
+		return moves[0][0], moves[0][1]
+	}
+	if moves := p.e.BlockingMoves(board, side); len(moves) > 0 {
+		return moves[0][0], moves[0][1]
+	}
+	return p.random.Play(board, side)
+}
+
+// Done does nothing.
+func (p *Tactical) Done(winner int) {}
+
+// Name returns the player name.
+func (p *Tactical) Name() string {
+	return "tactical"
+}
+
+// Lines returns the number of lines of the engine's target length through each position of the board.
+func Lines(e *game.Engine) [][]int {
+	lines := make([][]int, e.Rows())
+	for i := range lines {
+		lines[i] = make([]int, e.Columns())
+	}
+	for i := 0; i < e.Rows(); i++ {
+		for j := 0; j < e.Columns(); j++ {
+			for _, d := range game.Directions() {
+				// a line starts at i, j if its last position is on the board
+				ei, ej := i+d[0]*(e.Target()-1), j+d[1]*(e.Target()-1)
+				if ei < 0 || ej < 0 || ei >= e.Rows() || ej >= e.Columns() {
+					continue
+				}
+				for k := 0; k < e.Target(); k++ {
+					lines[i+d[0]*k][j+d[1]*k]++
+				}
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,133 @@
+package baseline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/player"
+)
+
+func TestLines(t *testing.T) {
+	tt := []struct {
+		name                  string
+		rows, columns, target int
+		want                  [][]int
+	}{
+		{
+			name: "3x3",
+			rows: 3, columns: 3, target: 3,
+			want: [][]int{{3, 2, 3}, {2, 4, 2}, {3, 2, 3}},
+		},
+		{
+			name: "3x4",
+			rows: 3, columns: 4, target: 3,
+			want: [][]int{{3, 4, 4, 3}, {2, 5, 5, 2}, {3, 4, 4, 3}},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e, _ := game.NewEngine(tc.rows, tc.columns, tc.target)
+			if got := Lines(e); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Lines() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// moves plays a game of p1 against p2 and returns its history and winner.
+func moves(t *testing.T, e *game.Engine, p1, p2 player.Player) ([][]int, int) {
+	g, err := game.New(e, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g.Play() {
+	}
+	_, winner := g.Result()
+	return g.History(), winner
+}
+
+func TestSeed(t *testing.T) {
+	e, _ := game.NewEngine(5, 5, 4)
+	players := []func(int64) player.Player{
+		func(seed int64) player.Player { p, _ := NewRandom(e, seed); return p },
+		func(seed int64) player.Player { p, _ := NewCenter(e, seed); return p },
+		func(seed int64) player.Player { p, _ := NewTactical(e, seed); return p },
+	}
+	for _, x := range players {
+		for _, o := range players {
+			h1, w1 := moves(t, e, x(1), o(2))
+			h2, w2 := moves(t, e, x(1), o(2))
+			if !reflect.DeepEqual(h1, h2) || w1 != w2 {
+				t.Errorf("games of %v against %v with the same seeds differ", x(1).Name(), o(2).Name())
+			}
+		}
+	}
+	if _, err := NewRandom(nil, 0); err != game.ErrInvalidGameSpecs {
+		t.Errorf("NewRandom() error = %v, want %v", err, game.ErrInvalidGameSpecs)
+	}
+}
+
+func TestCenter_Play(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	p, _ := NewCenter(e, 1)
+	empty := [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	count := [3][3]int{}
+	for k := 0; k < 2000; k++ {
+		i, j := p.Play(empty, 1)
+		count[i][j]++
+	}
+	// the center is on 4 of the 24 line positions and every edge on 2
+	if count[1][1] < 250 || count[0][1] > count[1][1] {
+		t.Errorf("Center.Play() move counts = %v", count)
+	}
+	if i, j := p.Play([][]int{{1, 2, 1}, {2, 1, 2}, {2, 1, 2}}, 1); i != -1 || j != -1 {
+		t.Errorf("Center.Play() on a full board = (%v, %v), want (-1, -1)", i, j)
+	}
+}
+
+func TestTactical_Play(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	p, _ := NewTactical(e, 1)
+	tt := []struct {
+		name  string
+		board [][]int
+		side  int
+		i, j  int
+	}{
+		{
+			name:  "win before block",
+			board: [][]int{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}},
+			side:  1,
+			i:     0,
+			j:     2,
+		},
+		{
+			name:  "block",
+			board: [][]int{{1, 1, 0}, {2, 0, 0}, {0, 0, 0}},
+			side:  2,
+			i:     0,
+			j:     2,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if i, j := p.Play(tc.board, tc.side); i != tc.i || j != tc.j {
+				t.Errorf("Tactical.Play() = (%v, %v), want (%v, %v)", i, j, tc.i, tc.j)
+			}
+		})
+	}
+
+	r, _ := NewRandom(e, 2)
+	var wins, losses int
+	for k := 0; k < 200; k++ {
+		if _, winner := moves(t, e, p, r); winner == 1 {
+			wins++
+		} else if winner == 2 {
+			losses++
+		}
+	}
+	if wins < 4*losses {
+		t.Errorf("tactical player won %v and lost %v games against a random player", wins, losses)
+	}
+}
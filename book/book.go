@@ -0,0 +1,316 @@
+// Package book builds opening books from played games and provides a player that plays from them.
+// Positions are stored in their canonical form under the board's symmetries, so a book built from
+// games played in one corner also covers the other corners.
+package book
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// ErrInvalidBook is returned when a book can not be read or does not match an engine.
+var ErrInvalidBook = errors.New("invalid book")
+
+// magic starts every book in binary format.
+var magic = [4]byte{'T', 'T', 'T', 'B'}
+
+// Stats are the results of the games in which a move was played, from the point of view
+// of the side that played the move.
+type Stats struct {
+	Wins   int `json:"wins"`
+	Draws  int `json:"draws"`
+	Losses int `json:"losses"`
+}
+
+// Games returns the number of games in which the move was played.
+func (s Stats) Games() int {
+	return s.Wins + s.Draws + s.Losses
+}
+
+// Score returns the average result of the move, 1 for a win, 0.5 for a draw and 0 for a loss.
+func (s Stats) Score() float64 {
+	if s.Games() == 0 {
+		return 0
+	}
+	return (float64(s.Wins) + float64(s.Draws)/2) / float64(s.Games())
+}
+
+// Entry is a book move of a position and its statistics.
+type Entry struct {
+	Move  []int
+	Stats Stats
+}
+
+// Book is an opening book for an engine's board.
+type Book struct {
+	e         *game.Engine
+	depth     int
+	positions map[string]map[int]*Stats // canonical board -> move index in the canonical board -> stats
+}
+
+// New returns a new empty opening book that stores the first depth moves of each game.
+func New(e *game.Engine, depth int) (*Book, error) {
+	if e == nil || depth < 1 {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Book{
+		e:         e,
+		depth:     depth,
+		positions: make(map[string]map[int]*Stats),
+	}, nil
+}
+
+// Len returns the number of positions in the book.
+func (b *Book) Len() int {
+	return len(b.positions)
+}
+
+// Add adds a game played from the empty board to the book. moves are the positions played in order,
+// such as TicTacToe's History, and winner is 0 for a draw, 1 for X and 2 for O.
+// The game is validated move by move and nothing is added if a move is illegal, or if the moves
+// finish the game with a result other than winner. Unfinished games, such as resigned ones, are
+// added with the given winner.
+func (b *Book) Add(moves [][]int, winner int) error {
+	if winner < 0 || winner > 2 {
+		return game.ErrInvalidSide
+	}
+	s, err := game.NewState(b.e, nil)
+	if err != nil {
+		return err
+	}
+	type played struct {
+		key   string
+		move  int
+		mover int
+	}
+	var plies []played
+	for k, m := range moves {
+		if len(m) != 2 {
+			return game.ErrIllegalMove
+		}
+		if k < b.depth {
+			canonical, i, j := game.CanonicalMove(s.Board(), m[0], m[1])
+			plies = append(plies, played{key: game.Key(canonical), move: i*b.e.Columns() + j, mover: s.Side()})
+		}
+		if err := s.Do(m[0], m[1]); err != nil {
+			return err
+		}
+	}
+	if inProgress, w := s.Result(); !inProgress && w != winner {
+		return game.ErrResultMismatch
+	}
+	for _, p := range plies {
+		moves, ok := b.positions[p.key]
+		if !ok {
+			moves = make(map[int]*Stats)
+			b.positions[p.key] = moves
+		}
+		st, ok := moves[p.move]
+		if !ok {
+			st = &Stats{}
+			moves[p.move] = st
+		}
+		switch winner {
+		case 0:
+			st.Draws++
+		case p.mover:
+			st.Wins++
+		default:
+			st.Losses++
+		}
+	}
+	return nil
+}
+
+// Lookup returns the book moves of board in board's own orientation, best scoring moves first.
+func (b *Book) Lookup(board [][]int) []Entry {
+	canonical, sym := game.Canonical(board)
+	moves := b.positions[game.Key(canonical)]
+	inv := sym.Inverse()
+	entries := make([]Entry, 0, len(moves))
+	for m, st := range moves {
+		i, j := inv.Apply(b.e.Rows(), b.e.Columns(), m/b.e.Columns(), m%b.e.Columns())
+		entries = append(entries, Entry{Move: []int{i, j}, Stats: *st})
+	}
+	sort.Slice(entries, func(x, y int) bool {
+		sx, sy := entries[x].Stats.Score(), entries[y].Stats.Score()
+		if sx != sy {
+			return sx > sy
+		}
+		if gx, gy := entries[x].Stats.Games(), entries[y].Stats.Games(); gx != gy {
+			return gx > gy
+		}
+		mx, my := entries[x].Move, entries[y].Move
+		return mx[0] < my[0] || (mx[0] == my[0] && mx[1] < my[1])
+	})
+	return entries
+}
+
+// Best returns the best scoring book move of board that was played in at least minGames games.
+func (b *Book) Best(board [][]int, minGames int) ([]int, bool) {
+	for _, entry := range b.Lookup(board) {
+		if entry.Stats.Games() >= minGames {
+			return entry.Move, true
+		}
+	}
+	return nil, false
+}
+
+type jsonBook struct {
+	Rows      int                      `json:"rows"`
+	Columns   int                      `json:"columns"`
+	Target    int                      `json:"target"`
+	Depth     int                      `json:"depth"`
+	Positions map[string]map[int]Stats `json:"positions"`
+}
+
+// WriteJSON writes the book to w in JSON format.
+// Positions are keyed by the game.Key of their canonical board and moves by their row major index.
+func (b *Book) WriteJSON(w io.Writer) error {
+	jb := jsonBook{
+		Rows:      b.e.Rows(),
+		Columns:   b.e.Columns(),
+		Target:    b.e.Target(),
+		Depth:     b.depth,
+		Positions: make(map[string]map[int]Stats, len(b.positions)),
+	}
+	for k, moves := range b.positions {
+		jb.Positions[k] = make(map[int]Stats, len(moves))
+		for m, st := range moves {
+			jb.Positions[k][m] = *st
+		}
+	}
+	return json.NewEncoder(w).Encode(jb)
+}
+
+// ReadJSON reads a book written by WriteJSON for the engine's board.
+func ReadJSON(r io.Reader, e *game.Engine) (*Book, error) {
+	var jb jsonBook
+	if err := json.NewDecoder(r).Decode(&jb); err != nil {
+		return nil, err
+	}
+	b, err := load(e, jb.Rows, jb.Columns, jb.Target, jb.Depth)
+	if err != nil {
+		return nil, err
+	}
+	for k, moves := range jb.Positions {
+		if len(k) != e.Rows()*e.Columns() {
+			return nil, ErrInvalidBook
+		}
+		b.positions[k] = make(map[int]*Stats, len(moves))
+		for m, st := range moves {
+			st := st
+			b.positions[k][m] = &st
+		}
+	}
+	return b, nil
+}
+
+// WriteBinary writes the book to w in a compact little endian binary format:
+// the magic "TTTB", rows, columns, target and depth as uint16 and the number of positions as uint32,
+// followed by each position as one byte per board position, the number of moves as uint16 and
+// each move as its row major index as uint16 and wins, draws and losses as uint32.
+// Positions are written in key order so the same book always produces the same output.
+func (b *Book) WriteBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header := []interface{}{
+		magic,
+		uint16(b.e.Rows()), uint16(b.e.Columns()), uint16(b.e.Target()), uint16(b.depth),
+		uint32(len(b.positions)),
+	}
+	for _, v := range header {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(b.positions))
+	for k := range b.positions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := bw.WriteString(k); err != nil {
+			return err
+		}
+		moves := b.positions[k]
+		indices := make([]int, 0, len(moves))
+		for m := range moves {
+			indices = append(indices, m)
+		}
+		sort.Ints(indices)
+		if err := binary.Write(bw, binary.LittleEndian, uint16(len(indices))); err != nil {
+			return err
+		}
+		for _, m := range indices {
+			st := moves[m]
+			record := []uint32{uint32(st.Wins), uint32(st.Draws), uint32(st.Losses)}
+			if err := binary.Write(bw, binary.LittleEndian, uint16(m)); err != nil {
+				return err
+			}
+			if err := binary.Write(bw, binary.LittleEndian, record); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadBinary reads a book written by WriteBinary for the engine's board.
+func ReadBinary(r io.Reader, e *game.Engine) (*Book, error) {
+	br := bufio.NewReader(r)
+	var header struct {
+		Magic                        [4]byte
+		Rows, Columns, Target, Depth uint16
+		Positions                    uint32
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.Magic != magic {
+		return nil, ErrInvalidBook
+	}
+	b, err := load(e, int(header.Rows), int(header.Columns), int(header.Target), int(header.Depth))
+	if err != nil {
+		return nil, err
+	}
+	k := make([]byte, e.Rows()*e.Columns())
+	for p := uint32(0); p < header.Positions; p++ {
+		if _, err := io.ReadFull(br, k); err != nil {
+			return nil, err
+		}
+		var n uint16
+		if err := binary.Read(br, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		moves := make(map[int]*Stats, n)
+		for m := uint16(0); m < n; m++ {
+			var index uint16
+			var record [3]uint32
+			if err := binary.Read(br, binary.LittleEndian, &index); err != nil {
+				return nil, err
+			}
+			if err := binary.Read(br, binary.LittleEndian, &record); err != nil {
+				return nil, err
+			}
+			moves[int(index)] = &Stats{Wins: int(record[0]), Draws: int(record[1]), Losses: int(record[2])}
+		}
+		b.positions[string(k)] = moves
+	}
+	return b, nil
+}
+
+func load(e *game.Engine, rows, columns, target, depth int) (*Book, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	if rows != e.Rows() || columns != e.Columns() || target != e.Target() {
+		return nil, ErrInvalidBook
+	}
+	return New(e, depth)
+}
@@ -0,0 +1,134 @@
+package book
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func testBook(t *testing.T) (*game.Engine, *Book) {
+	e, _ := game.NewEngine(3, 3, 3)
+	b, err := New(e, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	games := []struct {
+		moves  [][]int
+		winner int
+	}{
+		// X opens in the top left corner and wins
+		{moves: [][]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}}, winner: 1},
+		// X opens in the bottom right corner, which is the same position, and draws
+		{moves: [][]int{{2, 2}, {1, 1}}, winner: 0},
+		// X opens in the center and loses
+		{moves: [][]int{{1, 1}, {0, 0}}, winner: 2},
+	}
+	for _, g := range games {
+		if err := b.Add(g.moves, g.winner); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return e, b
+}
+
+func TestBook_Lookup(t *testing.T) {
+	_, b := testBook(t)
+	empty := [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	entries := b.Lookup(empty)
+	if len(entries) != 2 {
+		t.Fatalf("Book.Lookup() = %v, want 2 entries", entries)
+	}
+	if want := (Stats{Wins: 1, Draws: 1}); entries[0].Stats != want {
+		t.Errorf("Book.Lookup() corner stats = %v, want %v", entries[0].Stats, want)
+	}
+	if want := (Stats{Losses: 1}); entries[1].Stats != want || !reflect.DeepEqual(entries[1].Move, []int{1, 1}) {
+		t.Errorf("Book.Lookup() center = %v, want %v at 1, 1", entries[1], want)
+	}
+
+	// O's answers to X in the top right corner are found through symmetry
+	m, ok := b.Best([][]int{{0, 0, 1}, {0, 0, 0}, {0, 0, 0}}, 1)
+	if !ok {
+		t.Fatalf("Book.Best() found no move")
+	}
+	if !reflect.DeepEqual(m, []int{1, 1}) {
+		t.Errorf("Book.Best() = %v, want [1 1]", m)
+	}
+	if _, ok := b.Best(empty, 3); ok {
+		t.Errorf("Book.Best() with minGames 3 found a move")
+	}
+
+	if err := b.Add([][]int{{0, 0}, {0, 0}}, 1); err == nil {
+		t.Errorf("Book.Add() with an illegal move error = nil, want error")
+	}
+	n := b.Len()
+	if err := b.Add([][]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}}, 2); err != game.ErrResultMismatch {
+		t.Errorf("Book.Add() with the wrong winner error = %v, want %v", err, game.ErrResultMismatch)
+	}
+	if b.Len() != n {
+		t.Errorf("Book.Add() with the wrong winner added positions")
+	}
+}
+
+func TestBook_ReadWrite(t *testing.T) {
+	e, b := testBook(t)
+	var buf bytes.Buffer
+	if err := b.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := ReadJSON(&buf, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, b) {
+		t.Errorf("ReadJSON() = %v, want %v", fromJSON, b)
+	}
+
+	buf.Reset()
+	if err := b.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := ReadBinary(&buf, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromBinary, b) {
+		t.Errorf("ReadBinary() = %v, want %v", fromBinary, b)
+	}
+
+	other, _ := game.NewEngine(4, 4, 3)
+	buf.Reset()
+	b.WriteBinary(&buf)
+	if _, err := ReadBinary(&buf, other); err != ErrInvalidBook {
+		t.Errorf("ReadBinary() for another engine error = %v, want %v", err, ErrInvalidBook)
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	_, b := testBook(t)
+	fallback := &fixedPlayer{move: []int{2, 1}}
+	p, err := NewPlayer(b, fallback, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, j := p.Play([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 1); i != 0 || j != 0 {
+		t.Errorf("Player.Play() = %v, %v, want the book move 0, 0", i, j)
+	}
+	if i, j := p.Play([][]int{{0, 0, 0}, {0, 1, 2}, {0, 0, 0}}, 1); i != 2 || j != 1 {
+		t.Errorf("Player.Play() = %v, %v, want the fallback move 2, 1", i, j)
+	}
+	p.Done(1)
+	if fallback.winner != 1 {
+		t.Errorf("Player.Done() did not inform the fallback player")
+	}
+}
+
+type fixedPlayer struct {
+	move   []int
+	winner int
+}
+
+func (p *fixedPlayer) Play(board [][]int, side int) (int, int) { return p.move[0], p.move[1] }
+func (p *fixedPlayer) Done(winner int)                         { p.winner = winner }
+func (p *fixedPlayer) Name() string                            { return "fixed" }
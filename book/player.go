@@ -0,0 +1,40 @@
+package book
+
+import (
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/player"
+)
+
+// Player is a player.Player that plays the best book move while the position is in the book,
+// and delegates to another player afterwards.
+type Player struct {
+	b        *Book
+	fallback player.Player
+	minGames int
+}
+
+// NewPlayer returns a new book backed player. Book moves played in fewer than minGames games are ignored.
+func NewPlayer(b *Book, fallback player.Player, minGames int) (*Player, error) {
+	if b == nil || fallback == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Player{b: b, fallback: fallback, minGames: minGames}, nil
+}
+
+// Play returns the best book move, or the fallback player's move when there is none.
+func (p *Player) Play(board [][]int, side int) (int, int) {
+	if m, ok := p.b.Best(board, p.minGames); ok && p.b.e.Legal(board, m[0], m[1]) {
+		return m[0], m[1]
+	}
+	return p.fallback.Play(board, side)
+}
+
+// Done informs the fallback player that the current game is over.
+func (p *Player) Done(winner int) {
+	p.fallback.Done(winner)
+}
+
+// Name returns the fallback player's name.
+func (p *Player) Name() string {
+	return p.fallback.Name()
+}
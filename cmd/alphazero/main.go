@@ -0,0 +1,59 @@
+// Command alphazero trains a policy and value network for a TicTacToe board by self-play.
+//
+// Usage:
+//
+//	alphazero -rows 3 -columns 3 -target 3 -iterations 20 -dir checkpoints
+//
+// Every iteration writes the trained network to dir/checkpoint-NNNN.json and, if it wins the gating
+// match against the best network so far, to dir/best.json. Training resumes from dir/best.json and
+// numbers iterations on from the last checkpoint.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/mraufc/tictactoe/alphazero"
+	"github.com/mraufc/tictactoe/game"
+)
+
+func main() {
+	cfg := alphazero.DefaultConfig()
+	rows := flag.Int("rows", 3, "number of board rows")
+	columns := flag.Int("columns", 3, "number of board columns")
+	target := flag.Int("target", 3, "number of consecutive symbols that win")
+	iterations := flag.Int("iterations", 10, "number of training iterations")
+	flag.IntVar(&cfg.Hidden, "hidden", cfg.Hidden, "hidden layer size of a new network")
+	flag.IntVar(&cfg.Simulations, "simulations", cfg.Simulations, "search simulations per move")
+	flag.Float64Var(&cfg.CPuct, "cpuct", cfg.CPuct, "search exploration weight")
+	flag.IntVar(&cfg.Games, "games", cfg.Games, "self-play games per iteration")
+	flag.IntVar(&cfg.SampledMoves, "sampled", cfg.SampledMoves, "moves per game sampled by visit count")
+	flag.IntVar(&cfg.Window, "window", cfg.Window, "number of recent iterations to train on")
+	flag.IntVar(&cfg.Epochs, "epochs", cfg.Epochs, "training epochs per iteration")
+	flag.IntVar(&cfg.BatchSize, "batch", cfg.BatchSize, "minibatch size")
+	lr := flag.Float64("lr", float64(cfg.LearningRate), "learning rate")
+	flag.IntVar(&cfg.GatingGames, "gating-games", cfg.GatingGames, "games of the gating match")
+	flag.Float64Var(&cfg.GatingThreshold, "threshold", cfg.GatingThreshold, "gating score needed to replace the best network")
+	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "random seed")
+	flag.StringVar(&cfg.Dir, "dir", "checkpoints", "checkpoint directory")
+	flag.Parse()
+	cfg.LearningRate = float32(*lr)
+
+	e, err := game.NewEngine(*rows, *columns, *target)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p, err := alphazero.NewPipeline(e, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for k := 0; k < *iterations; k++ {
+		r, err := p.Iterate()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("iteration %d: samples %d, loss %.4f, gating score %.2f, accepted %v\n",
+			r.Iteration, r.Samples, r.Loss, r.Score, r.Accepted)
+	}
+}
@@ -0,0 +1,135 @@
+// Command rl trains a tabular Q-learning player and saves its table.
+//
+// Usage:
+//
+//	rl -rows 3 -columns 3 -target 3 -games 50000 -opponent self -table q.json
+//
+// The opponent is either "self", the learning player plays both sides, or one of the baseline
+// players "random", "center" and "tactical".
+// Against an opponent the learning player alternates between X and O and the progress reports count
+// its wins, draws and losses. In self play they count the wins of X and O and the draws. Training
+// resumes from the table file if it exists.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mraufc/tictactoe/baseline"
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/player"
+	"github.com/mraufc/tictactoe/rl"
+)
+
+func main() {
+	cfg := rl.DefaultConfig()
+	rows := flag.Int("rows", 3, "number of board rows")
+	columns := flag.Int("columns", 3, "number of board columns")
+	target := flag.Int("target", 3, "number of consecutive symbols that win")
+	games := flag.Int("games", 50000, "number of training games")
+	opponent := flag.String("opponent", "self", "opponent: self, random, center or tactical")
+	file := flag.String("table", "q.json", "table file")
+	every := flag.Int("report", 5000, "games between progress reports")
+	flag.Float64Var(&cfg.Alpha, "alpha", cfg.Alpha, "learning rate")
+	flag.Float64Var(&cfg.Gamma, "gamma", cfg.Gamma, "discount")
+	flag.Float64Var(&cfg.Exploration.Start, "epsilon", cfg.Exploration.Start, "initial exploration probability")
+	flag.Float64Var(&cfg.Exploration.End, "epsilon-end", cfg.Exploration.End, "final exploration probability")
+	flag.IntVar(&cfg.Exploration.Games, "decay", cfg.Exploration.Games, "games over which exploration decreases")
+	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "random seed")
+	flag.Parse()
+
+	e, err := game.NewEngine(*rows, *columns, *target)
+	if err != nil {
+		log.Fatal(err)
+	}
+	table, err := load(*file, e)
+	if err != nil {
+		log.Fatal(err)
+	}
+	learner, err := rl.NewPlayer(table, "learner", cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opp, err := newOpponent(*opponent, e, learner, cfg.Seed+1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	self := *opponent == "self"
+	// results holds the draws and the wins of X and O in self play, or the draws, wins and losses of
+	// the learning player against an opponent
+	var results [3]int
+	for k := 1; k <= *games; k++ {
+		side := 1 + (k-1)%2
+		var g *game.TicTacToe
+		if side == 1 {
+			g, err = game.New(e, learner, opp)
+		} else {
+			g, err = game.New(e, opp, learner)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		for g.Play() {
+		}
+		switch _, winner := g.Result(); {
+		case self, winner == 0:
+			results[winner]++
+		case winner == side:
+			results[1]++
+		default:
+			results[2]++
+		}
+		if *every > 0 && k%*every == 0 {
+			if self {
+				fmt.Printf("games %d: X wins %d, O wins %d, draws %d, positions %d\n", k, results[1], results[2], results[0], table.Len())
+			} else {
+				fmt.Printf("games %d: wins %d, draws %d, losses %d, positions %d\n", k, results[1], results[0], results[2], table.Len())
+			}
+			results = [3]int{}
+		}
+	}
+	if err := save(*file, table); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newOpponent(name string, e *game.Engine, learner *rl.Player, seed int64) (player.Player, error) {
+	switch name {
+	case "self":
+		return learner, nil
+	case "random":
+		return baseline.NewRandom(e, seed)
+	case "center":
+		return baseline.NewCenter(e, seed)
+	case "tactical":
+		return baseline.NewTactical(e, seed)
+	}
+	return nil, fmt.Errorf("unknown opponent %q", name)
+}
+
+func load(name string, e *game.Engine) (*rl.Table, error) {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return rl.NewTable(e)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return rl.Read(f, e)
+}
+
+func save(name string, t *rl.Table) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := t.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
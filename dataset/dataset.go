@@ -0,0 +1,217 @@
+// Package dataset converts game records into training samples for neural networks and exports them
+// as NPY arrays or a simple binary format.
+//
+// Every position of a game is one sample with 3 feature planes of rows x columns values:
+// the stones of the side to move, the stones of the opponent and a plane of ones when X is to move
+// (zeros when O is to move). Each sample also has a legal move mask, a one-hot policy target of the
+// move played and the outcome of the game from the point of view of the side to move.
+package dataset
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// Planes is the number of feature planes of a sample.
+const Planes = 3
+
+// magic starts every dataset in binary format.
+var magic = [4]byte{'T', 'T', 'T', 'D'}
+
+// Record is a game played from the empty board, such as a TicTacToe's History and Result.
+type Record struct {
+	Moves  [][]int `json:"moves"`
+	Winner int     `json:"winner"` // 0 is a draw, 1 is X and 2 is O
+}
+
+// Sample is a single training sample.
+type Sample struct {
+	Features []float32 // Planes x rows x columns
+	Mask     []float32 // rows x columns, 1 for legal moves
+	Policy   []float32 // rows x columns, 1 for the move played
+	Value    float32   // 1 if the side to move won, -1 if it lost and 0 for a draw
+}
+
+// Features returns the feature planes of board with side to move.
+func Features(e *game.Engine, board [][]int, side int) []float32 {
+	rows, columns := e.Rows(), e.Columns()
+	features := make([]float32, Planes*rows*columns)
+	for i, row := range board {
+		for j, v := range row {
+			p := i*columns + j
+			switch v {
+			case side:
+				features[p] = 1
+			case 3 - side:
+				features[rows*columns+p] = 1
+			}
+			if side == 1 {
+				features[2*rows*columns+p] = 1
+			}
+		}
+	}
+	return features
+}
+
+// Mask returns the legal move mask of board.
+func Mask(e *game.Engine, board [][]int) []float32 {
+	mask := make([]float32, e.Rows()*e.Columns())
+	for _, m := range e.LegalMoves(board) {
+		mask[m[0]*e.Columns()+m[1]] = 1
+	}
+	return mask
+}
+
+// Dataset is a set of samples for an engine's board.
+type Dataset struct {
+	e       *game.Engine
+	Samples []Sample
+}
+
+// New returns a new empty dataset for the engine's board.
+func New(e *game.Engine) (*Dataset, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Dataset{e: e}, nil
+}
+
+// Add replays a game record and adds a sample for every position in which a move was played.
+// Nothing is added if a move is illegal, or if the moves finish the game with a result other than
+// the record's winner.
+func (d *Dataset) Add(r Record) error {
+	if r.Winner < 0 || r.Winner > 2 {
+		return game.ErrInvalidSide
+	}
+	s, err := game.NewState(d.e, nil)
+	if err != nil {
+		return err
+	}
+	var samples []Sample
+	for _, m := range r.Moves {
+		if len(m) != 2 {
+			return game.ErrIllegalMove
+		}
+		board, side := s.Board(), s.Side()
+		sample := Sample{
+			Features: Features(d.e, board, side),
+			Mask:     Mask(d.e, board),
+			Policy:   make([]float32, d.e.Rows()*d.e.Columns()),
+		}
+		if err := s.Do(m[0], m[1]); err != nil {
+			return err
+		}
+		sample.Policy[m[0]*d.e.Columns()+m[1]] = 1
+		switch r.Winner {
+		case 0:
+		case side:
+			sample.Value = 1
+		default:
+			sample.Value = -1
+		}
+		samples = append(samples, sample)
+	}
+	if inProgress, winner := s.Result(); !inProgress && winner != r.Winner {
+		return game.ErrResultMismatch
+	}
+	d.Samples = append(d.Samples, samples...)
+	return nil
+}
+
+// SaveNPY writes the dataset into dir as four NPY files: features.npy of shape (N, 3, rows, columns),
+// mask.npy and policy.npy of shape (N, rows * columns) and value.npy of shape (N), all float32.
+func (d *Dataset) SaveNPY(dir string) error {
+	rows, columns := d.e.Rows(), d.e.Columns()
+	n := len(d.Samples)
+	arrays := []struct {
+		name  string
+		shape []int
+		data  func(s Sample) []float32
+	}{
+		{"features.npy", []int{n, Planes, rows, columns}, func(s Sample) []float32 { return s.Features }},
+		{"mask.npy", []int{n, rows * columns}, func(s Sample) []float32 { return s.Mask }},
+		{"policy.npy", []int{n, rows * columns}, func(s Sample) []float32 { return s.Policy }},
+		{"value.npy", []int{n}, func(s Sample) []float32 { return []float32{s.Value} }},
+	}
+	for _, a := range arrays {
+		data := make([]float32, 0, n)
+		for _, s := range d.Samples {
+			data = append(data, a.data(s)...)
+		}
+		f, err := os.Create(filepath.Join(dir, a.name))
+		if err != nil {
+			return err
+		}
+		if err := WriteNPY(f, a.shape, data); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteNPY writes a little endian float32 array of the given shape in NPY format version 1.0.
+func WriteNPY(w io.Writer, shape []int, data []float32) error {
+	size := 1
+	dims := make([]string, len(shape))
+	for k, s := range shape {
+		size *= s
+		dims[k] = fmt.Sprint(s)
+	}
+	if size != len(data) {
+		return fmt.Errorf("dataset: shape %v does not match %d values", shape, len(data))
+	}
+	tuple := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	header := fmt.Sprintf("{'descr': '<f4', 'fortran_order': False, 'shape': (%s), }", tuple)
+	// magic, version and header length take 10 bytes, the header is padded to a multiple of 64
+	// and ends with a new line
+	pad := 64 - (10+len(header)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	header += strings.Repeat(" ", pad) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+	if err := binary.Write(bw, binary.LittleEndian, data); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// WriteBinary writes the dataset to w in a simple little endian binary format: the magic "TTTD",
+// the number of samples, planes, rows and columns as uint32, followed by each sample's features,
+// mask, policy and value as float32.
+func (d *Dataset) WriteBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header := []uint32{uint32(len(d.Samples)), Planes, uint32(d.e.Rows()), uint32(d.e.Columns())}
+	if err := binary.Write(bw, binary.LittleEndian, magic); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	for _, s := range d.Samples {
+		for _, v := range [][]float32{s.Features, s.Mask, s.Policy, {s.Value}} {
+			if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,113 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func TestDataset_Add(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	d, _ := New(e)
+	// X wins on the top row
+	r := Record{Moves: [][]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}}, Winner: 1}
+	if err := d.Add(r); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Samples) != 5 {
+		t.Fatalf("Dataset.Add() added %v samples, want 5", len(d.Samples))
+	}
+	second := d.Samples[1]
+	wantFeatures := []float32{
+		// O's stones, O is to move
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		// X's stones
+		1, 0, 0, 0, 0, 0, 0, 0, 0,
+		// O is to move
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !reflect.DeepEqual(second.Features, wantFeatures) {
+		t.Errorf("Sample.Features = %v, want %v", second.Features, wantFeatures)
+	}
+	if want := []float32{0, 1, 1, 1, 1, 1, 1, 1, 1}; !reflect.DeepEqual(second.Mask, want) {
+		t.Errorf("Sample.Mask = %v, want %v", second.Mask, want)
+	}
+	if want := []float32{0, 0, 0, 1, 0, 0, 0, 0, 0}; !reflect.DeepEqual(second.Policy, want) {
+		t.Errorf("Sample.Policy = %v, want %v", second.Policy, want)
+	}
+	for k, s := range d.Samples {
+		want := float32(1)
+		if k%2 == 1 {
+			want = -1
+		}
+		if s.Value != want {
+			t.Errorf("sample %v Value = %v, want %v", k, s.Value, want)
+		}
+	}
+
+	if err := d.Add(Record{Moves: [][]int{{0, 0}, {0, 0}}}); err == nil {
+		t.Errorf("Dataset.Add() with an illegal move error = nil, want error")
+	}
+	if len(d.Samples) != 5 {
+		t.Errorf("Dataset.Add() with an illegal move added samples")
+	}
+	if err := d.Add(Record{Moves: [][]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}}, Winner: 0}); err != game.ErrResultMismatch {
+		t.Errorf("Dataset.Add() with the wrong winner error = %v, want %v", err, game.ErrResultMismatch)
+	}
+	if len(d.Samples) != 5 {
+		t.Errorf("Dataset.Add() with the wrong winner added samples")
+	}
+}
+
+func TestWriteNPY(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, []int{2}, []float32{1, -1}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	if string(b[:8]) != "\x93NUMPY\x01\x00" {
+		t.Fatalf("WriteNPY() magic = %q", b[:8])
+	}
+	headerLen := int(binary.LittleEndian.Uint16(b[8:10]))
+	if (10+headerLen)%64 != 0 {
+		t.Errorf("WriteNPY() header is not aligned: %v", 10+headerLen)
+	}
+	header := string(b[10 : 10+headerLen])
+	if want := "{'descr': '<f4', 'fortran_order': False, 'shape': (2,), }"; header[:len(want)] != want {
+		t.Errorf("WriteNPY() header = %q, want %q", header, want)
+	}
+	if len(b) != 10+headerLen+8 {
+		t.Errorf("WriteNPY() wrote %v bytes, want %v", len(b), 10+headerLen+8)
+	}
+	if err := WriteNPY(&buf, []int{3}, []float32{1}); err == nil {
+		t.Errorf("WriteNPY() with wrong shape error = nil, want error")
+	}
+}
+
+func TestDataset_Save(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	d, _ := New(e)
+	d.Add(Record{Moves: [][]int{{1, 1}, {0, 0}}, Winner: 0})
+	dir := t.TempDir()
+	if err := d.SaveNPY(dir); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"features.npy", "mask.npy", "policy.npy", "value.npy"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Dataset.SaveNPY() did not write %v: %v", name, err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := d.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// header, then 2 samples of 27 features, 9 mask, 9 policy and 1 value
+	if want := 4 + 16 + 2*(27+9+9+1)*4; buf.Len() != want {
+		t.Errorf("Dataset.WriteBinary() wrote %v bytes, want %v", buf.Len(), want)
+	}
+}
@@ -1,5 +1,8 @@
 package game
 
+// Blocked marks a position that no side can occupy. Blocked positions break lines for both sides.
+const Blocked = 3
+
 // Engine is the game engine that evaluates moves for a board of size rows by columns.
 type Engine struct {
 	target  int
@@ -19,6 +22,21 @@ func NewEngine(rows, columns, target int) (*Engine, error) {
 	}, nil
 }
 
+// Rows returns the number of rows of the engine's board.
+func (e *Engine) Rows() int {
+	return e.rows
+}
+
+// Columns returns the number of columns of the engine's board.
+func (e *Engine) Columns() int {
+	return e.columns
+}
+
+// Target returns the number of consecutive symbols needed to win.
+func (e *Engine) Target() int {
+	return e.target
+}
+
 // Evaluate evalutes a hypothetical board position and a side's move.
 // board parameter and the TicTacToe's instance board sizes must match.
 // Side is 1 for X Player and 2 for O Player.
@@ -26,6 +44,13 @@ func NewEngine(rows, columns, target int) (*Engine, error) {
 // if the game is over. Winner can be 0 for draw, 1 for X Player and 2 for O Player.
 // TODO: maybe "side" and "winner" can be enums.
 func (e *Engine) Evaluate(board [][]int, side, i, j int) (gameOver bool, winner int, err error) {
+	gameOver, winner, _, err = e.EvaluateLine(board, side, i, j)
+	return
+}
+
+// EvaluateLine is Evaluate that also returns the line of target or more symbols that the move completes,
+// or nil if the move does not win the game.
+func (e *Engine) EvaluateLine(board [][]int, side, i, j int) (gameOver bool, winner int, line *Line, err error) {
 	if board == nil {
 		err = ErrInvalidBoard
 		return
@@ -50,113 +75,144 @@ func (e *Engine) Evaluate(board [][]int, side, i, j int) (gameOver bool, winner
 			}
 		}
 	}
-	gameOver, winner = e.evaluate(board, side, i, j, unoccupied)
+	gameOver, winner, line = e.evaluateLine(board, side, i, j, unoccupied)
 	return
 }
 
-func (e *Engine) evaluate(board [][]int, side, i, j, unoccupied int) (bool, int) {
-	// if there are no unoccupied positions left, the game is already over
-	if unoccupied == 0 {
-		return true, 0
-	}
-	// if the player makes an invalid move or the move position is already occupied,
-	// that player loses immediately.
-	if i < 0 || j < 0 || i >= len(board) || j >= len(board) || board[i][j] != 0 {
-		if side == 1 {
-			return true, 2 // winner is 2 (O)
-		}
-		return true, 1 // winner is 1 (X)
-	}
+// Validate validates a position that a game can start from and returns the side to move.
+// Positions must be of the engine's size, contain only 0, 1, 2 and Blocked values,
+// have the same number of X's and O's (X to move) or one more X than O's (O to move),
+// and must not be over yet.
+func (e *Engine) Validate(board [][]int) (side int, err error) {
+	side, gameOver, _, err := e.outcome(board)
+	if err != nil {
+		return 0, err
+	}
+	if gameOver {
+		return 0, ErrInvalidBoard
+	}
+	return side, nil
+}
 
-	minI, maxI := 0, len(board)-1
-	if i-e.target+1 > minI {
-		minI = i - e.target + 1
-	}
-	if i+e.target-1 < maxI {
-		maxI = i + e.target - 1
+// outcome validates a position like Validate but also accepts positions where the game is over.
+// It returns the side to move, whether the game is over and the winner. A won position must have
+// a single winner that made the last move: X with one more X than O's, or O with as many X's as O's.
+func (e *Engine) outcome(board [][]int) (side int, gameOver bool, winner int, err error) {
+	if !e.fits(board) {
+		return 0, false, 0, ErrInvalidBoard
+	}
+	x, o, unoccupied := 0, 0, 0
+	for _, row := range board {
+		for _, v := range row {
+			switch v {
+			case 0:
+				unoccupied++
+			case 1:
+				x++
+			case 2:
+				o++
+			case Blocked:
+			default:
+				return 0, false, 0, ErrInvalidBoard
+			}
+		}
 	}
+	switch {
+	case x == o:
+		side = 1
+	case x == o+1:
+		side = 2
+	default:
+		return 0, false, 0, ErrInvalidBoard
+	}
+	xLine, oLine := e.hasLine(board, 1), e.hasLine(board, 2)
+	switch {
+	case xLine && oLine, xLine && side != 2, oLine && side != 1:
+		return 0, false, 0, ErrInvalidBoard
+	case xLine:
+		return side, true, 1, nil
+	case oLine:
+		return side, true, 2, nil
+	}
+	return side, unoccupied == 0, 0, nil
+}
 
-	minJ, maxJ := 0, len(board)-1
-	if j-e.target+1 > minJ {
-		minJ = j - e.target + 1
-	}
-	if j+e.target-1 < maxJ {
-		maxJ = j + e.target - 1
-	}
+// hasLine returns whether side has target consecutive symbols anywhere on the board.
+func (e *Engine) hasLine(board [][]int, side int) bool {
+	return e.findLine(board, side) != nil
+}
 
-	// check vertical
-	cnt := 1
-	for k := 1; i-k >= minI; k++ {
-		if board[i-k][j] != side {
-			break
-		}
-		cnt++
-	}
-	for k := 1; i+k <= maxI; k++ {
-		if board[i+k][j] != side {
-			break
+// findLine returns the first line of target or more consecutive symbols of side on the board in row major
+// order of its start, or nil if there is none.
+func (e *Engine) findLine(board [][]int, side int) *Line {
+	for i := 0; i < e.rows; i++ {
+		for j := 0; j < e.columns; j++ {
+			if board[i][j] != side {
+				continue
+			}
+			for _, d := range directions2D {
+				if e.at(board, i-d[0], j-d[1]) == side {
+					// not the start of the line
+					continue
+				}
+				cnt := 1
+				for e.at(board, i+cnt*d[0], j+cnt*d[1]) == side {
+					cnt++
+				}
+				if cnt >= e.target {
+					return newLine(side, i, j, d, cnt)
+				}
+			}
 		}
-		cnt++
-	}
-	if cnt >= e.target {
-		return true, side
 	}
+	return nil
+}
 
-	// check horizontal
-	cnt = 1
-	for k := 1; j-k >= minJ; k++ {
-		if board[i][j-k] != side {
-			break
-		}
-		cnt++
-	}
-	for k := 1; j+k <= maxJ; k++ {
-		if board[i][j+k] != side {
-			break
-		}
-		cnt++
-	}
-	if cnt >= e.target {
-		return true, side
-	}
+func (e *Engine) evaluate(board [][]int, side, i, j, unoccupied int) (bool, int) {
+	gameOver, winner, _ := e.evaluateLine(board, side, i, j, unoccupied)
+	return gameOver, winner
+}
 
-	// check diagonal upper left to lower right
-	cnt = 1
-	for k := 1; i-k >= minI && j-k >= minJ; k++ {
-		if board[i-k][j-k] != side {
-			break
-		}
-		cnt++
+// evaluateLine is evaluate that also returns the line the move completes, or nil if it completes none.
+func (e *Engine) evaluateLine(board [][]int, side, i, j, unoccupied int) (bool, int, *Line) {
+	// if there are no unoccupied positions left, the game is already over
+	if unoccupied == 0 {
+		return true, 0, nil
 	}
-	for k := 1; i+k <= maxI && j+k <= maxJ; k++ {
-		if board[i+k][j+k] != side {
-			break
+	// if the player makes an invalid move or the move position is already occupied,
+	// that player loses immediately.
+	if i < 0 || j < 0 || i >= e.rows || j >= e.columns || board[i][j] != 0 {
+		if side == 1 {
+			return true, 2, nil // winner is 2 (O)
 		}
-		cnt++
-	}
-	if cnt >= e.target {
-		return true, side
+		return true, 1, nil // winner is 1 (X)
 	}
 
-	// check diagonal upper right to lower left
-	cnt = 1
-	for k := 1; i-k >= minI && j+k <= maxJ; k++ {
-		if board[i-k][j+k] != side {
-			break
+	// check horizontal, vertical, upper left to lower right and upper right to lower left in order.
+	// Counting stops target - 1 positions away from the move, no line of target symbols existed before it.
+	for _, d := range directions2D {
+		back := 0
+		for k := 1; k < e.target && e.at(board, i-k*d[0], j-k*d[1]) == side; k++ {
+			back++
 		}
-		cnt++
-	}
-	for k := 1; i+k <= maxI && j-k >= minJ; k++ {
-		if board[i+k][j-k] != side {
-			break
+		forward := 0
+		for k := 1; k < e.target && e.at(board, i+k*d[0], j+k*d[1]) == side; k++ {
+			forward++
+		}
+		if back+1+forward >= e.target {
+			return true, side, newLine(side, i-back*d[0], j-back*d[1], d, back+1+forward)
 		}
-		cnt++
-	}
-	if cnt >= e.target {
-		return true, side
 	}
 	if unoccupied == 1 {
-		return true, 0
+		return true, 0, nil
+	}
+	return false, 0, nil
+}
+
+// at returns the value of position i, j of board, or -1 if it is outside of the board.
+func (e *Engine) at(board [][]int, i, j int) int {
+	if i < 0 || j < 0 || i >= e.rows || j >= e.columns {
+		return -1
 	}
-	return false, 0
+	return board[i][j]
 }
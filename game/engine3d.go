@@ -0,0 +1,118 @@
+package game
+
+// directions3D holds one vector for each of the 13 line directions of a 3D board.
+// The opposite directions are covered by walking each vector both ways.
+var directions3D = [13][3]int{
+	{1, 0, 0}, {0, 1, 0}, {0, 0, 1},
+	{1, 1, 0}, {1, -1, 0},
+	{1, 0, 1}, {1, 0, -1},
+	{0, 1, 1}, {0, 1, -1},
+	{1, 1, 1}, {1, 1, -1}, {1, -1, 1}, {1, -1, -1},
+}
+
+// Engine3D is the game engine that evaluates moves for a board of size rows by columns by depth.
+type Engine3D struct {
+	plane *Engine
+	depth int
+}
+
+// NewEngine3D returns a new three dimensional game engine.
+// rows, columns and target are validated the same way NewEngine does, and depth must be
+// greater than or equal to 3 and target.
+func NewEngine3D(rows, columns, depth, target int) (*Engine3D, error) {
+	plane, err := NewEngine(rows, columns, target)
+	if err != nil {
+		return nil, err
+	}
+	if depth < 3 || target > depth {
+		return nil, ErrInvalidGameSpecs
+	}
+	return &Engine3D{
+		plane: plane,
+		depth: depth,
+	}, nil
+}
+
+// Evaluate evaluates a hypothetical board position and a side's move to i, j, k.
+// board parameter and the engine's sizes must match, board is indexed as board[row][column][depth].
+// Side is 1 for X Player and 2 for O Player.
+// Evaluate function returns whether the game will be over after the move, and the winner of the game
+// if the game is over. Winner can be 0 for draw, 1 for X Player and 2 for O Player.
+func (e *Engine3D) Evaluate(board [][][]int, side, i, j, k int) (gameOver bool, winner int, err error) {
+	if board == nil {
+		err = ErrInvalidBoard
+		return
+	}
+	if side != 1 && side != 2 {
+		err = ErrInvalidSide
+		return
+	}
+	if len(board) != e.plane.rows {
+		err = ErrInvalidBoard
+		return
+	}
+	unoccupied := 0
+	for _, row := range board {
+		if len(row) != e.plane.columns {
+			err = ErrInvalidBoard
+			return
+		}
+		for _, column := range row {
+			if len(column) != e.depth {
+				err = ErrInvalidBoard
+				return
+			}
+			for _, v := range column {
+				if v == 0 {
+					unoccupied++
+				}
+			}
+		}
+	}
+	gameOver, winner = e.evaluate(board, side, i, j, k, unoccupied)
+	return
+}
+
+func (e *Engine3D) evaluate(board [][][]int, side, i, j, k, unoccupied int) (bool, int) {
+	// if there are no unoccupied positions left, the game is already over
+	if unoccupied == 0 {
+		return true, 0
+	}
+	// if the player makes an invalid move or the move position is already occupied,
+	// that player loses immediately.
+	if !e.inside(i, j, k) || board[i][j][k] != 0 {
+		if side == 1 {
+			return true, 2 // winner is 2 (O)
+		}
+		return true, 1 // winner is 1 (X)
+	}
+
+	for _, d := range directions3D {
+		cnt := 1
+		for n := 1; n < e.plane.target; n++ {
+			a, b, c := i-n*d[0], j-n*d[1], k-n*d[2]
+			if !e.inside(a, b, c) || board[a][b][c] != side {
+				break
+			}
+			cnt++
+		}
+		for n := 1; n < e.plane.target; n++ {
+			a, b, c := i+n*d[0], j+n*d[1], k+n*d[2]
+			if !e.inside(a, b, c) || board[a][b][c] != side {
+				break
+			}
+			cnt++
+		}
+		if cnt >= e.plane.target {
+			return true, side
+		}
+	}
+	if unoccupied == 1 {
+		return true, 0
+	}
+	return false, 0
+}
+
+func (e *Engine3D) inside(i, j, k int) bool {
+	return i >= 0 && j >= 0 && k >= 0 && i < e.plane.rows && j < e.plane.columns && k < e.depth
+}
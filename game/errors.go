@@ -11,3 +11,21 @@ var ErrInvalidGameSpecs = errors.New("invalid game specifications")
 
 // ErrInvalidSide is returned when side is invalid
 var ErrInvalidSide = errors.New("invalid side")
+
+// ErrInvalidWeights is returned when heuristic weights do not match the engine's target
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// ErrIllegalMove is returned when a move is made to an occupied position or outside of the board
+var ErrIllegalMove = errors.New("illegal move")
+
+// ErrGameOver is returned when a move is made after the game is over
+var ErrGameOver = errors.New("game is over")
+
+// ErrNoMoves is returned when there are no moves to undo
+var ErrNoMoves = errors.New("no moves to undo")
+
+// ErrInvalidFormat is returned when a text format's symbols are empty, repeated or contain spaces
+var ErrInvalidFormat = errors.New("invalid format")
+
+// ErrResultMismatch is returned when the winner of a game record does not match the result of its moves
+var ErrResultMismatch = errors.New("result does not match moves")
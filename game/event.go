@@ -0,0 +1,104 @@
+package game
+
+import "time"
+
+// EventType is the type of a game event.
+type EventType int
+
+const (
+	// EventStart is sent when Play is called for the first time.
+	EventStart EventType = iota
+	// EventMoveRequested is sent before a player is asked for its move.
+	EventMoveRequested
+	// EventMovePlayed is sent after a placement is made on the board.
+	EventMovePlayed
+	// EventIllegalMove is sent when a player makes an illegal move or choice and forfeits the game.
+	EventIllegalMove
+	// EventGameOver is sent when the game is over.
+	EventGameOver
+	// EventChoice is sent after a player chooses a side in a swap based opening.
+	EventChoice
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventStart:
+		return "start"
+	case EventMoveRequested:
+		return "move requested"
+	case EventMovePlayed:
+		return "move played"
+	case EventIllegalMove:
+		return "illegal move"
+	case EventGameOver:
+		return "game over"
+	case EventChoice:
+		return "choice"
+	}
+	return "unknown"
+}
+
+// Event is a notification about a game of TicTacToe.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Side is the side to move for EventMoveRequested, the side that moved for EventMovePlayed
+	// and EventIllegalMove and the side of the player that chose for EventChoice, 0 otherwise.
+	Side int
+	// Count is the number of placements requested by EventMoveRequested.
+	Count int
+	// Choice is the option chosen in EventChoice, which may be invalid: 1 and 2 choose a side
+	// and 0 lets the other player choose in OpeningSwap2.
+	Choice int
+	// Move is the position of EventMovePlayed and EventIllegalMove. It is nil for illegal moves
+	// that are not a single position, such as a wrong number of placements or an invalid choice.
+	Move []int
+	// Board is a copy of the board after the event.
+	Board [][]int
+	// Moves is the number of moves played so far.
+	Moves int
+	// Winner is the winner of EventGameOver, 0 for a draw.
+	Winner int
+	// Line is the winning line of EventGameOver, nil if the game is not won by a line.
+	Line *Line
+}
+
+// Observer is a function that is called with every event of a game.
+// Observers are called synchronously from Play in the order they were added.
+type Observer func(Event)
+
+// Observe adds an observer to the game.
+func (t *TicTacToe) Observe(o Observer) {
+	if o != nil {
+		t.observers = append(t.observers, o)
+	}
+}
+
+// Channel returns an observer that sends every event to ch.
+// Sends block, so ch must be buffered or read while the game is played.
+func Channel(ch chan<- Event) Observer {
+	return func(e Event) {
+		ch <- e
+	}
+}
+
+// emit sends an event of the current game state to the observers.
+func (t *TicTacToe) emit(e Event) {
+	if len(t.observers) == 0 {
+		return
+	}
+	e.Time = time.Now()
+	e.Board = CopyBoard(t.board)
+	e.Moves = t.moves
+	for _, o := range t.observers {
+		o(e)
+	}
+}
+
+// forfeit ends the game because of an illegal move or choice of side.
+func (t *TicTacToe) forfeit(side int, move []int) {
+	t.emit(Event{Type: EventIllegalMove, Side: side, Move: move})
+	t.line = nil
+	t.finish(3 - side)
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/player"
+)
+
+func TestTicTacToe_Observe(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	tt := []struct {
+		name    string
+		p1, p2  func() player.Player
+		opening Opening
+		want    []Event
+	}{
+		{
+			name: "win",
+			p1:   func() player.Player { return NewTestPlayer([][]int{{0, 0}, {1, 1}, {2, 2}}, "X") },
+			p2:   func() player.Player { return NewTestPlayer([][]int{{0, 1}, {0, 2}}, "O") },
+			want: []Event{
+				{Type: EventStart},
+				{Type: EventMoveRequested, Side: 1, Count: 1},
+				{Type: EventMovePlayed, Side: 1, Move: []int{0, 0}, Moves: 1},
+				{Type: EventMoveRequested, Side: 2, Count: 1, Moves: 1},
+				{Type: EventMovePlayed, Side: 2, Move: []int{0, 1}, Moves: 2},
+				{Type: EventMoveRequested, Side: 1, Count: 1, Moves: 2},
+				{Type: EventMovePlayed, Side: 1, Move: []int{1, 1}, Moves: 3},
+				{Type: EventMoveRequested, Side: 2, Count: 1, Moves: 3},
+				{Type: EventMovePlayed, Side: 2, Move: []int{0, 2}, Moves: 4},
+				{Type: EventMoveRequested, Side: 1, Count: 1, Moves: 4},
+				{Type: EventMovePlayed, Side: 1, Move: []int{2, 2}, Moves: 5},
+				{Type: EventGameOver, Winner: 1, Moves: 5, Line: &Line{Side: 1, Start: []int{0, 0}, End: []int{2, 2},
+					Direction: [2]int{1, 1}, Cells: [][]int{{0, 0}, {1, 1}, {2, 2}}}},
+			},
+		},
+		{
+			name: "illegal move",
+			p1:   func() player.Player { return NewTestPlayer([][]int{{1, 1}}, "X") },
+			p2:   func() player.Player { return NewTestPlayer([][]int{{1, 1}}, "O") },
+			want: []Event{
+				{Type: EventStart},
+				{Type: EventMoveRequested, Side: 1, Count: 1},
+				{Type: EventMovePlayed, Side: 1, Move: []int{1, 1}, Moves: 1},
+				{Type: EventMoveRequested, Side: 2, Count: 1, Moves: 1},
+				{Type: EventIllegalMove, Side: 2, Move: []int{1, 1}, Moves: 1},
+				{Type: EventGameOver, Winner: 1, Moves: 1},
+			},
+		},
+		{
+			name: "pie",
+			p1: func() player.Player {
+				return &testOpeningPlayer{TestPlayer: NewTestPlayer([][]int{{1, 1}, {1, 1}}, "X")}
+			},
+			p2: func() player.Player {
+				return &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "O"), choices: []int{1}}
+			},
+			opening: OpeningPie,
+			want: []Event{
+				{Type: EventStart},
+				{Type: EventMoveRequested, Side: 1, Count: 1},
+				{Type: EventMovePlayed, Side: 1, Move: []int{1, 1}, Moves: 1},
+				{Type: EventChoice, Side: 2, Choice: 1, Moves: 1},
+				// the first player plays O after the swap
+				{Type: EventMoveRequested, Side: 2, Count: 1, Moves: 1},
+				{Type: EventIllegalMove, Side: 2, Move: []int{1, 1}, Moves: 1},
+				{Type: EventGameOver, Winner: 1, Moves: 1},
+			},
+		},
+		{
+			name: "swap2",
+			p1: func() player.Player {
+				return &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "X"), places: [][]int{{0, 0}, {2, 2}, {0, 1}}, choices: []int{2}}
+			},
+			p2: func() player.Player {
+				return &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "O"), places: [][]int{{1, 1}, {2, 0}}, choices: []int{0}}
+			},
+			opening: OpeningSwap2,
+			want: []Event{
+				{Type: EventStart},
+				{Type: EventMoveRequested, Side: 1, Count: 3},
+				{Type: EventMovePlayed, Side: 1, Move: []int{0, 0}, Moves: 1},
+				{Type: EventMovePlayed, Side: 2, Move: []int{2, 2}, Moves: 2},
+				{Type: EventMovePlayed, Side: 1, Move: []int{0, 1}, Moves: 3},
+				{Type: EventChoice, Side: 2, Choice: 0, Moves: 3},
+				{Type: EventMoveRequested, Side: 2, Count: 2, Moves: 3},
+				{Type: EventMovePlayed, Side: 2, Move: []int{1, 1}, Moves: 4},
+				{Type: EventMovePlayed, Side: 1, Move: []int{2, 0}, Moves: 5},
+				{Type: EventChoice, Side: 1, Choice: 2, Moves: 5},
+				// the first player chose O and has no moves left
+				{Type: EventMoveRequested, Side: 2, Count: 1, Moves: 5},
+				{Type: EventIllegalMove, Side: 2, Move: []int{0, 0}, Moves: 5},
+				{Type: EventGameOver, Winner: 1, Moves: 5},
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g, _ := New(e, tc.p1(), tc.p2())
+			if err := g.SetOpening(tc.opening); err != nil {
+				t.Fatal(err)
+			}
+			var got []Event
+			g.Observe(func(ev Event) {
+				got = append(got, ev)
+			})
+			ch := make(chan Event, 100)
+			g.Observe(Channel(ch))
+			for g.Play() {
+			}
+			g.Play()
+			close(ch)
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v events, want %v: %v", len(got), len(tc.want), got)
+			}
+			for k, ev := range got {
+				if ev.Time.IsZero() || (k > 0 && ev.Time.Before(got[k-1].Time)) {
+					t.Errorf("event %v time = %v", k, ev.Time)
+				}
+				if len(ev.Board) != 3 {
+					t.Errorf("event %v board = %v", k, ev.Board)
+				}
+				sent := <-ch
+				if !reflect.DeepEqual(sent, ev) {
+					t.Errorf("event %v sent to the channel = %+v, want %+v", k, sent, ev)
+				}
+				ev.Time, ev.Board = tc.want[k].Time, nil
+				if !reflect.DeepEqual(ev, tc.want[k]) {
+					t.Errorf("event %v = %+v, want %+v", k, ev, tc.want[k])
+				}
+			}
+			if last := got[len(got)-1].Board; !reflect.DeepEqual(last, g.Board()) {
+				t.Errorf("board of the last event = %v, want %v", last, g.Board())
+			}
+		})
+	}
+}
+
+func TestTicTacToe_ObserveOpening(t *testing.T) {
+	e, _ := NewEngine(5, 5, 4)
+	p1 := &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "X"), places: [][]int{{0, 0}, {1, 1}, {2, 2}}}
+	p2 := &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "O")}
+	g, _ := New(e, p1, p2)
+	if err := g.SetOpening(OpeningSwap); err != nil {
+		t.Fatal(err)
+	}
+	var types []EventType
+	g.Observe(func(ev Event) {
+		types = append(types, ev.Type)
+	})
+	for g.Play() {
+	}
+	// O does not choose a side and forfeits
+	want := []EventType{EventStart, EventMoveRequested, EventMovePlayed, EventMovePlayed, EventMovePlayed,
+		EventChoice, EventIllegalMove, EventGameOver}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("event types = %v, want %v", types, want)
+	}
+	if _, winner := g.Result(); winner != 1 {
+		t.Errorf("winner = %v, want 1", winner)
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"fmt"
 
 	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/game/gametest"
 )
 
 func ExampleTicTacToe_Pretty() {
-	p1 := game.NewTestPlayer([][]int{[]int{0, 0}, []int{0, 1}, []int{0, 2}, []int{0, 4}}, "Player 1")
-	p2 := game.NewTestPlayer([][]int{[]int{1, 0}, []int{1, 1}, []int{1, 2}, []int{1, 3}}, "Player 2")
+	p1 := gametest.NewScripted([][]int{[]int{0, 0}, []int{0, 1}, []int{0, 2}, []int{0, 4}}, "Player 1")
+	p2 := gametest.NewScripted([][]int{[]int{1, 0}, []int{1, 1}, []int{1, 2}, []int{1, 3}}, "Player 2")
 	engine, err := game.NewEngine(6, 6, 4)
 	if err != nil {
 		fmt.Println(err)
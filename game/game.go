@@ -13,13 +13,24 @@ import (
 // First player to reach a certain number (indicated by Engine's target) of X's or O's vertically, horizontally
 // or diagonally wins the game.
 type TicTacToe struct {
-	board    [][]int // 0 -> empty position, 1 -> X, 2 -> O
+	board    [][]int // 0 -> empty position, 1 -> X, 2 -> O, 3 -> Blocked
 	player1  player.Player
 	player2  player.Player
 	winner   int // 0 is a tie or draw
 	gameOver bool
 	moves    int
 	e        *Engine
+	// stones is the number of placements per turn and firstStones is the number of placements
+	// of the first turn, 0 is treated as 1.
+	stones      int
+	firstStones int
+	opening     Opening
+	opened      bool // whether the opening protocol is complete
+	blocked     int  // number of blocked positions
+	history     [][]int
+	line        *Line // winning line
+	observers   []Observer
+	started     bool
 }
 
 // New returns a new game of TicTacToe.
@@ -41,64 +52,192 @@ func New(engine *Engine, player1, player2 player.Player) (*TicTacToe, error) {
 	}, nil
 }
 
+// NewFromPosition returns a new game of TicTacToe that starts from the given position.
+// The position may contain X's, O's and Blocked positions and is validated by the engine.
+// The side to move is X when both sides have the same number of symbols on the board, and O
+// when X has one more symbol.
+func NewFromPosition(engine *Engine, board [][]int, player1, player2 player.Player) (*TicTacToe, error) {
+	t, err := New(engine, player1, player2)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := engine.Validate(board); err != nil {
+		return nil, err
+	}
+	for i, row := range board {
+		for j, v := range row {
+			t.board[i][j] = v
+			switch v {
+			case 1, 2:
+				t.moves++
+			case Blocked:
+				t.blocked++
+			}
+		}
+	}
+	return t, nil
+}
+
+// NewConnect returns a new game of a Connect(m,n,k,p,q) variant where every turn consists of
+// p placements, except for the first turn which consists of q placements.
+// For example Connect6 is NewConnect on a 19x19 engine with target 6, p = 2 and q = 1.
+func NewConnect(engine *Engine, p, q int, player1, player2 player.MultiPlayer) (*TicTacToe, error) {
+	if p < 1 || q < 1 || player1 == nil || player2 == nil {
+		return nil, ErrInvalidGameSpecs
+	}
+	t, err := New(engine, player1, player2)
+	if err != nil {
+		return nil, err
+	}
+	t.stones = p
+	t.firstStones = q
+	return t, nil
+}
+
 // Play calls the Play function of the appropriate player and evaluates the move and board.
+// Players that implement player.MultiPlayer are asked for all placements of the turn at once
+// and each placement is evaluated in order.
 // This function returns true as long as game is not over.
 func (t *TicTacToe) Play() bool {
 	if t.gameOver {
 		return false
 	}
+	if !t.started {
+		t.started = true
+		t.emit(Event{Type: EventStart})
+	}
+	if !t.opened && t.playOpening() {
+		return !t.gameOver
+	}
 
+	side, count := t.turn()
+	unoccupied := t.unoccupied()
+	if count > unoccupied {
+		count = unoccupied
+	}
+	t.emit(Event{Type: EventMoveRequested, Side: side, Count: count})
 	// pass a copy of the board to the player
-	cpy := make([][]int, len(t.board))
-	for i, row := range t.board {
-		cpy[i] = make([]int, len(row))
-		for j, v := range row {
-			cpy[i][j] = v
-		}
+	cpy := CopyBoard(t.board)
+	p := t.player1
+	if side == 2 {
+		p = t.player2
 	}
-	var i, j, side int
-	if t.moves%2 == 0 {
-		side = 1
-		i, j = t.player1.Play(cpy, 1)
+	var positions [][]int
+	if mp, ok := p.(player.MultiPlayer); ok {
+		positions = mp.PlayMulti(cpy, side, count)
 	} else {
-		side = 2
-		i, j = t.player2.Play(cpy, 2)
+		i, j := p.Play(cpy, side)
+		positions = [][]int{[]int{i, j}}
 	}
-	t.gameOver, t.winner = t.e.evaluate(t.board, side, i, j, len(t.board)*len(t.board)-t.moves)
-	if t.gameOver {
-		t.player1.Done(t.winner)
-		t.player2.Done(t.winner)
-		// illegal move, do not update the board
-		if t.winner != side && t.winner != 0 {
+	if len(positions) != count {
+		// wrong number of placements is an illegal move
+		t.forfeit(side, nil)
+		return false
+	}
+
+	for _, pos := range positions {
+		i, j := -1, -1
+		if len(pos) == 2 {
+			i, j = pos[0], pos[1]
+		}
+		move := []int{i, j}
+		if t.moves == 0 && t.opening == OpeningCenter && !t.e.center(i, j) {
+			// first move outside of the center is an illegal move
+			i, j = -1, -1
+		}
+		gameOver, winner, line := t.e.evaluateLine(t.board, side, i, j, t.unoccupied())
+		if gameOver && winner != side && winner != 0 {
+			// illegal move, do not update the board
+			t.forfeit(side, move)
 			return false
 		}
+		t.board[i][j] = side
+		t.moves++
+		t.history = append(t.history, []int{i, j})
+		t.emit(Event{Type: EventMovePlayed, Side: side, Move: []int{i, j}})
+		if gameOver {
+			t.line = line
+			t.finish(winner)
+			break
+		}
 	}
-	t.board[i][j] = side
-	t.moves++
 	return !t.gameOver
 }
 
+// unoccupied returns the number of unoccupied positions on the board.
+func (t *TicTacToe) unoccupied() int {
+	return t.e.rows*t.e.columns - t.moves - t.blocked
+}
+
+func (t *TicTacToe) finish(winner int) {
+	t.gameOver = true
+	t.winner = winner
+	t.player1.Done(winner)
+	t.player2.Done(winner)
+	t.emit(Event{Type: EventGameOver, Winner: winner, Line: t.WinningLine()})
+}
+
+// turn returns the side to move and the number of placements left in the current turn.
+func (t *TicTacToe) turn() (side, count int) {
+	p, q := t.stones, t.firstStones
+	if p < 1 {
+		p = 1
+	}
+	if q < 1 {
+		q = 1
+	}
+	if t.moves < q {
+		return 1, q - t.moves
+	}
+	n := t.moves - q
+	if (n/p)%2 == 0 {
+		return 2, p - n%p
+	}
+	return 1, p - n%p
+}
+
 // Result returns if the game is still in progress and the winner
 func (t *TicTacToe) Result() (bool, int) {
 	return !t.gameOver, t.winner
 }
 
-// Pretty returns a pretty string representation of the board
+// Engine returns the game's engine.
+func (t *TicTacToe) Engine() *Engine {
+	return t.e
+}
+
+// WinningLine returns the line that won the game, or nil if the game is not won by a line:
+// it is in progress, drawn or won because of an illegal move.
+func (t *TicTacToe) WinningLine() *Line {
+	if t.line == nil {
+		return nil
+	}
+	l := *t.line
+	l.Start, l.End = []int{l.Start[0], l.Start[1]}, []int{l.End[0], l.End[1]}
+	l.Cells = CopyBoard(l.Cells)
+	return &l
+}
+
+// Board returns a copy of the current board.
+func (t *TicTacToe) Board() [][]int {
+	return CopyBoard(t.board)
+}
+
+// History returns the positions played so far in order, including opening placements.
+// Positions of the starting position of NewFromPosition are not part of the history.
+func (t *TicTacToe) History() [][]int {
+	return CopyBoard(t.history)
+}
+
+// Pretty returns a pretty string representation of the board, '#' is a Blocked position
 func (t *TicTacToe) Pretty() string {
-	size := len(t.board)
 	title := fmt.Sprintf("%v as 'X' vs. %v as 'O'\n", t.player1.Name(), t.player2.Name())
 	board := ""
-	for i := 0; i < size; i++ {
+	for _, row := range t.board {
 		line := ""
-		for j := 0; j < size; j++ {
-			if t.board[i][j] == 0 {
-				line += "-"
-			} else if t.board[i][j] == 1 {
-				line += "X"
-			} else if t.board[i][j] == 2 {
-				line += "O"
-			}
-			if j < size-1 {
+		for j, v := range row {
+			line += symbol(v)
+			if j < len(row)-1 {
 				line += " "
 			}
 		}
@@ -120,3 +259,13 @@ func (t *TicTacToe) Pretty() string {
 	}
 	return title + board + result
 }
+
+// CopyBoard returns a copy of board that shares no rows with it.
+func CopyBoard(board [][]int) [][]int {
+	cpy := make([][]int, len(board))
+	for i, row := range board {
+		cpy[i] = make([]int, len(row))
+		copy(cpy[i], row)
+	}
+	return cpy
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mraufc/tictactoe/player"
+)
+
+// TicTacToe3D is a Qubic style variant of TicTacToe played on a rows x columns x depth board.
+// First player to reach a certain number (indicated by Engine3D's target) of X's or O's along
+// any of the 13 line directions of the cube wins the game.
+type TicTacToe3D struct {
+	board    [][][]int // 0 -> empty position, 1 -> X, 2 -> O
+	player1  player.Player3D
+	player2  player.Player3D
+	winner   int // 0 is a tie or draw
+	gameOver bool
+	moves    int
+	e        *Engine3D
+}
+
+// New3D returns a new game of three dimensional TicTacToe.
+func New3D(engine *Engine3D, player1, player2 player.Player3D) (*TicTacToe3D, error) {
+	if engine == nil || player1 == nil || player2 == nil {
+		return nil, ErrInvalidGameSpecs
+	}
+	board := make([][][]int, engine.plane.rows)
+	for i := range board {
+		board[i] = make([][]int, engine.plane.columns)
+		for j := range board[i] {
+			board[i][j] = make([]int, engine.depth)
+		}
+	}
+	return &TicTacToe3D{
+		board:   board,
+		e:       engine,
+		player1: player1,
+		player2: player2,
+	}, nil
+}
+
+// Play calls the Play function of the appropriate player and evaluates the move and board.
+// This function returns true as long as game is not over.
+func (t *TicTacToe3D) Play() bool {
+	if t.gameOver {
+		return false
+	}
+
+	// pass a copy of the board to the player
+	cpy := make([][][]int, len(t.board))
+	for i, row := range t.board {
+		cpy[i] = make([][]int, len(row))
+		for j, column := range row {
+			cpy[i][j] = make([]int, len(column))
+			copy(cpy[i][j], column)
+		}
+	}
+	var i, j, k, side int
+	if t.moves%2 == 0 {
+		side = 1
+		i, j, k = t.player1.Play(cpy, 1)
+	} else {
+		side = 2
+		i, j, k = t.player2.Play(cpy, 2)
+	}
+	unoccupied := t.e.plane.rows*t.e.plane.columns*t.e.depth - t.moves
+	t.gameOver, t.winner = t.e.evaluate(t.board, side, i, j, k, unoccupied)
+	if t.gameOver {
+		t.player1.Done(t.winner)
+		t.player2.Done(t.winner)
+		// illegal move, do not update the board
+		if t.winner != side && t.winner != 0 {
+			return false
+		}
+	}
+	t.board[i][j][k] = side
+	t.moves++
+	return !t.gameOver
+}
+
+// Result returns if the game is still in progress and the winner
+func (t *TicTacToe3D) Result() (bool, int) {
+	return !t.gameOver, t.winner
+}
+
+// Pretty returns a pretty string representation of the board, one depth layer after another
+func (t *TicTacToe3D) Pretty() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v as 'X' vs. %v as 'O'\n", t.player1.Name(), t.player2.Name())
+	for k := 0; k < t.e.depth; k++ {
+		fmt.Fprintf(&sb, "Layer %d\n", k)
+		for i := range t.board {
+			for j := range t.board[i] {
+				switch t.board[i][j][k] {
+				case 0:
+					sb.WriteString("-")
+				case 1:
+					sb.WriteString("X")
+				case 2:
+					sb.WriteString("O")
+				}
+				if j < len(t.board[i])-1 {
+					sb.WriteString(" ")
+				}
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if !t.gameOver {
+		sb.WriteString("Game is still in progress")
+	} else {
+		switch t.winner {
+		case 1:
+			fmt.Fprintf(&sb, "Winner is %v as 'X'", t.player1.Name())
+		case 2:
+			fmt.Fprintf(&sb, "Winner is %v as 'O'", t.player2.Name())
+		case 0:
+			sb.WriteString("Game is a Draw!")
+		}
+	}
+	return sb.String()
+}
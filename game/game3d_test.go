@@ -0,0 +1,173 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewEngine3D(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int
+		columns int
+		depth   int
+		target  int
+		wantErr bool
+	}{
+		{name: "valid 4x4x4, target: 4", rows: 4, columns: 4, depth: 4, target: 4, wantErr: false},
+		{name: "valid 3x4x5, target: 3", rows: 3, columns: 4, depth: 5, target: 3, wantErr: false},
+		{name: "invalid depth 2", rows: 4, columns: 4, depth: 2, target: 3, wantErr: true},
+		{name: "invalid target greater than depth", rows: 4, columns: 4, depth: 3, target: 4, wantErr: true},
+		{name: "invalid target greater than rows", rows: 3, columns: 4, depth: 4, target: 4, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEngine3D(tt.rows, tt.columns, tt.depth, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewEngine3D() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEngine3D_Evaluate(t *testing.T) {
+	empty := func() [][][]int {
+		board := make([][][]int, 4)
+		for i := range board {
+			board[i] = make([][]int, 4)
+			for j := range board[i] {
+				board[i][j] = make([]int, 4)
+			}
+		}
+		return board
+	}
+	spaceDiagonal := empty()
+	spaceDiagonal[0][0][0], spaceDiagonal[1][1][1], spaceDiagonal[2][2][2] = 1, 1, 1
+	antiDiagonal := empty()
+	antiDiagonal[0][3][3], antiDiagonal[1][2][2], antiDiagonal[3][0][0] = 2, 2, 2
+	depthLine := empty()
+	depthLine[1][2][0], depthLine[1][2][1], depthLine[1][2][3] = 1, 1, 1
+	broken := empty()
+	broken[0][0][0], broken[1][1][1], broken[2][2][2] = 1, 2, 1
+
+	type args struct {
+		board   [][][]int
+		side    int
+		i, j, k int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantGameOver bool
+		wantWinner   int
+		wantErr      bool
+	}{
+		{
+			name:         "X wins along the space diagonal",
+			args:         args{board: spaceDiagonal, side: 1, i: 3, j: 3, k: 3},
+			wantGameOver: true,
+			wantWinner:   1,
+		},
+		{
+			name:         "O wins along the anti space diagonal",
+			args:         args{board: antiDiagonal, side: 2, i: 2, j: 1, k: 1},
+			wantGameOver: true,
+			wantWinner:   2,
+		},
+		{
+			name:         "X wins along the depth",
+			args:         args{board: depthLine, side: 1, i: 1, j: 2, k: 2},
+			wantGameOver: true,
+			wantWinner:   1,
+		},
+		{
+			name:         "X does not win through an O",
+			args:         args{board: broken, side: 1, i: 3, j: 3, k: 3},
+			wantGameOver: false,
+			wantWinner:   0,
+		},
+		{
+			name:         "X plays outside of the depth",
+			args:         args{board: empty(), side: 1, i: 0, j: 0, k: 4},
+			wantGameOver: true,
+			wantWinner:   2,
+		},
+		{
+			name:    "invalid board depth",
+			args:    args{board: [][][]int{{{0}}}, side: 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid side",
+			args:    args{board: empty(), side: 3},
+			wantErr: true,
+		},
+	}
+	e, err := NewEngine3D(4, 4, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotGameOver, gotWinner, err := e.Evaluate(tt.args.board, tt.args.side, tt.args.i, tt.args.j, tt.args.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Engine3D.Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotGameOver != tt.wantGameOver {
+				t.Errorf("Engine3D.Evaluate() gotGameOver = %v, want %v", gotGameOver, tt.wantGameOver)
+			}
+			if gotWinner != tt.wantWinner {
+				t.Errorf("Engine3D.Evaluate() gotWinner = %v, want %v", gotWinner, tt.wantWinner)
+			}
+		})
+	}
+}
+
+func TestTicTacToe3D_Play(t *testing.T) {
+	e, err := NewEngine3D(3, 3, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1 := &testPlayer3D{moves: [][]int{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}}, name: "p1"}
+	p2 := &testPlayer3D{moves: [][]int{{0, 1, 0}, {0, 2, 0}}, name: "p2"}
+	g, err := New3D(e, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g.Play() {
+	}
+	inProgress, winner := g.Result()
+	if inProgress || winner != 1 {
+		t.Errorf("TicTacToe3D.Result() = %v, %v, want false, 1", inProgress, winner)
+	}
+	if !p1.gameOver || p1.winner != 1 || !p2.gameOver || p2.winner != 1 {
+		t.Errorf("TicTacToe3D.Play() players were not informed of the result")
+	}
+}
+
+// testPlayer3D implements Player3D interface
+type testPlayer3D struct {
+	counter  int
+	moves    [][]int
+	name     string
+	winner   int
+	gameOver bool
+}
+
+func (tp *testPlayer3D) Name() string {
+	return tp.name
+}
+
+func (tp *testPlayer3D) Play(board [][][]int, side int) (int, int, int) {
+	if tp.counter >= len(tp.moves) {
+		return 0, 0, 0
+	}
+	m := tp.moves[tp.counter]
+	tp.counter++
+	return m[0], m[1], m[2]
+}
+
+func (tp *testPlayer3D) Done(winner int) {
+	tp.gameOver = true
+	tp.winner = winner
+}
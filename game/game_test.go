@@ -137,6 +137,171 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewFromPosition(t *testing.T) {
+	e, _ := NewEngine(4, 4, 3)
+	tests := []struct {
+		name      string
+		board     [][]int
+		wantMoves int
+		wantSide  int
+		wantErr   bool
+	}{
+		{
+			name: "blocked positions only, X to move",
+			board: [][]int{
+				[]int{0, 0, 0, 0},
+				[]int{0, 3, 0, 0},
+				[]int{0, 0, 3, 0},
+				[]int{0, 0, 0, 0},
+			},
+			wantMoves: 0,
+			wantSide:  1,
+		},
+		{
+			name: "X and blocked positions, O to move",
+			board: [][]int{
+				[]int{1, 0, 0, 0},
+				[]int{0, 3, 0, 0},
+				[]int{0, 1, 2, 0},
+				[]int{0, 0, 0, 0},
+			},
+			wantMoves: 3,
+			wantSide:  2,
+		},
+		{
+			name: "too many O's",
+			board: [][]int{
+				[]int{2, 2, 0, 0},
+				[]int{0, 0, 0, 0},
+				[]int{0, 1, 0, 0},
+				[]int{0, 0, 0, 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "game is already over",
+			board: [][]int{
+				[]int{1, 1, 1, 0},
+				[]int{2, 2, 0, 0},
+				[]int{0, 0, 0, 0},
+				[]int{0, 0, 0, 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid position value",
+			board: [][]int{
+				[]int{4, 0, 0, 0},
+				[]int{0, 0, 0, 0},
+				[]int{0, 0, 0, 0},
+				[]int{0, 0, 0, 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid column count",
+			board: [][]int{
+				[]int{0, 0, 0},
+				[]int{0, 0, 0},
+				[]int{0, 0, 0},
+				[]int{0, 0, 0},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromPosition(e, tt.board, NewTestPlayer(nil, "X"), NewTestPlayer(nil, "O"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFromPosition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.moves != tt.wantMoves {
+				t.Errorf("NewFromPosition() moves = %v, want %v", got.moves, tt.wantMoves)
+			}
+			if side, _ := got.turn(); side != tt.wantSide {
+				t.Errorf("NewFromPosition() side to move = %v, want %v", side, tt.wantSide)
+			}
+			if !reflect.DeepEqual(got.board, tt.board) {
+				t.Errorf("NewFromPosition() board = %v, want %v", got.board, tt.board)
+			}
+		})
+	}
+}
+
+func TestTicTacToe_PlayBlocked(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	board := [][]int{
+		[]int{1, 3, 0},
+		[]int{2, 0, 0},
+		[]int{0, 0, 0},
+	}
+	p1 := NewTestPlayer([][]int{[]int{0, 2}, []int{2, 2}, []int{2, 0}}, "X")
+	p2 := NewTestPlayer([][]int{[]int{0, 1}}, "O")
+	g, err := NewFromPosition(e, board, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// X's row is broken by the blocked position
+	if !g.Play() {
+		t.Fatalf("TicTacToe.Play() = false, want true")
+	}
+	// O plays the blocked position and loses
+	if g.Play() {
+		t.Fatalf("TicTacToe.Play() = true, want false")
+	}
+	if inProgress, winner := g.Result(); inProgress || winner != 1 {
+		t.Errorf("TicTacToe.Result() = %v, %v, want false, 1", inProgress, winner)
+	}
+	want := "X as 'X' vs. O as 'O'\nX # X\nO - -\n- - -\nWinner is X as 'X'"
+	if got := g.Pretty(); got != want {
+		t.Errorf("TicTacToe.Pretty() = %q, want %q", got, want)
+	}
+}
+
+func TestTicTacToe_PrettyRectangular(t *testing.T) {
+	e, _ := NewEngine(3, 5, 3)
+	board := [][]int{
+		[]int{1, 0, 0, 0, 3},
+		[]int{0, 0, 2, 0, 0},
+		[]int{0, 0, 0, 0, 0},
+	}
+	g, err := NewFromPosition(e, board, NewTestPlayer(nil, "X"), NewTestPlayer(nil, "O"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "X as 'X' vs. O as 'O'\nX - - - #\n- - O - -\n- - - - -\nGame is still in progress"
+	if got := g.Pretty(); got != want {
+		t.Errorf("TicTacToe.Pretty() = %q, want %q", got, want)
+	}
+}
+
+func TestTicTacToe_PlayRectangular(t *testing.T) {
+	e, _ := NewEngine(3, 5, 3)
+	p1 := NewTestPlayer([][]int{
+		[]int{0, 0}, []int{0, 1}, []int{0, 4}, []int{1, 2}, []int{1, 3}, []int{2, 0}, []int{2, 1}, []int{2, 4},
+	}, "X")
+	p2 := NewTestPlayer([][]int{
+		[]int{0, 2}, []int{0, 3}, []int{1, 0}, []int{1, 1}, []int{1, 4}, []int{2, 2}, []int{2, 3},
+	}, "O")
+	g, err := New(e, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g.Play() {
+	}
+	// every one of the 15 positions is played before the game is drawn
+	if inProgress, winner := g.Result(); inProgress || winner != 0 {
+		t.Errorf("TicTacToe.Result() = %v, %v, want false, 0", inProgress, winner)
+	}
+	if n := len(g.History()); n != 15 {
+		t.Errorf("len(TicTacToe.History()) = %v, want 15", n)
+	}
+}
+
 func TestTicTacToe_Play(t *testing.T) {
 	type want struct {
 		result   bool
@@ -529,6 +694,68 @@ func TestTicTacToe_Play(t *testing.T) {
 	}
 }
 
+func TestTicTacToe_PlayConnect(t *testing.T) {
+	e, err := NewEngine(8, 8, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		p          int
+		q          int
+		moves1     [][]int
+		moves2     [][]int
+		wantMoves  int
+		wantWinner int
+	}{
+		{
+			name:       "Connect(8,8,5,2,1) X wins with the second stone of a turn",
+			p:          2,
+			q:          1,
+			moves1:     [][]int{[]int{0, 0}, []int{0, 1}, []int{0, 2}, []int{0, 3}, []int{0, 4}},
+			moves2:     [][]int{[]int{7, 0}, []int{7, 1}, []int{7, 3}, []int{7, 4}},
+			wantMoves:  9,
+			wantWinner: 1,
+		},
+		{
+			name:       "Connect(8,8,5,2,2) O wins with the first stone of a turn",
+			p:          2,
+			q:          2,
+			moves1:     [][]int{[]int{0, 0}, []int{0, 1}, []int{5, 5}, []int{5, 6}, []int{3, 3}, []int{3, 4}},
+			moves2:     [][]int{[]int{7, 0}, []int{7, 1}, []int{7, 2}, []int{7, 3}, []int{7, 4}, []int{7, 5}},
+			wantMoves:  11,
+			wantWinner: 2,
+		},
+		{
+			name:       "Connect(8,8,5,2,1) placing an occupied position loses",
+			p:          2,
+			q:          1,
+			moves1:     [][]int{[]int{0, 0}},
+			moves2:     [][]int{[]int{1, 1}, []int{0, 0}},
+			wantMoves:  2,
+			wantWinner: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := &testMultiPlayer{NewTestPlayer(tt.moves1, "X")}
+			p2 := &testMultiPlayer{NewTestPlayer(tt.moves2, "O")}
+			g, err := NewConnect(e, tt.p, tt.q, p1, p2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for g.Play() {
+			}
+			if g.moves != tt.wantMoves {
+				t.Errorf("TicTacToe.Play() moves = %v, want %v", g.moves, tt.wantMoves)
+			}
+			if g.winner != tt.wantWinner {
+				t.Errorf("TicTacToe.Play() winner = %v, want %v", g.winner, tt.wantWinner)
+			}
+		})
+	}
+}
+
 func TestTicTacToe_Evaluate(t *testing.T) {
 	type args struct {
 		board [][]int
@@ -738,6 +965,52 @@ func TestTicTacToe_Evaluate(t *testing.T) {
 			wantWinner:   0,
 			wantErr:      true,
 		},
+		{
+			name: "rectangular board, valid X move to column 4",
+			t: &TicTacToe{
+				e: &Engine{
+					rows:    3,
+					columns: 5,
+					target:  3,
+				},
+			},
+			args: args{
+				board: [][]int{
+					[]int{0, 0, 0, 0, 0},
+					[]int{2, 2, 0, 0, 0},
+					[]int{0, 0, 0, 0, 0},
+				},
+				side: 1,
+				i:    0,
+				j:    4,
+			},
+			wantGameOver: false,
+			wantWinner:   0,
+			wantErr:      false,
+		},
+		{
+			name: "rectangular board, X wins with a move to column 4",
+			t: &TicTacToe{
+				e: &Engine{
+					rows:    3,
+					columns: 5,
+					target:  3,
+				},
+			},
+			args: args{
+				board: [][]int{
+					[]int{0, 0, 1, 1, 0},
+					[]int{2, 2, 0, 0, 0},
+					[]int{0, 0, 0, 0, 0},
+				},
+				side: 1,
+				i:    0,
+				j:    4,
+			},
+			wantGameOver: true,
+			wantWinner:   1,
+			wantErr:      false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -786,3 +1059,17 @@ func (tp *TestPlayer) Done(winner int) {
 	tp.gameOver = true
 	tp.winner = winner
 }
+
+// testMultiPlayer implements MultiPlayer interface
+type testMultiPlayer struct {
+	*TestPlayer
+}
+
+func (tp *testMultiPlayer) PlayMulti(board [][]int, side, count int) [][]int {
+	positions := make([][]int, 0, count)
+	for k := 0; k < count; k++ {
+		i, j := tp.Play(board, side)
+		positions = append(positions, []int{i, j})
+	}
+	return positions
+}
@@ -0,0 +1,172 @@
+// Package gametest provides players, board builders and assertions for testing code that uses the game package.
+package gametest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/player"
+)
+
+// Scripted is a player.Player that plays a fixed list of moves in order.
+// Once the moves run out it plays -1, -1, an illegal move that forfeits the game.
+type Scripted struct {
+	moves    [][]int
+	name     string
+	played   int
+	winner   int
+	gameOver bool
+}
+
+// NewScripted returns a new scripted player that plays moves in order.
+func NewScripted(moves [][]int, name string) *Scripted {
+	return &Scripted{moves: moves, name: name}
+}
+
+// Play returns the next move of the script.
+func (p *Scripted) Play(board [][]int, side int) (int, int) {
+	if p.played >= len(p.moves) {
+		return -1, -1
+	}
+	m := p.moves[p.played]
+	p.played++
+	return m[0], m[1]
+}
+
+// Done records the end of the game.
+func (p *Scripted) Done(winner int) {
+	p.gameOver = true
+	p.winner = winner
+}
+
+// Name returns the player name.
+func (p *Scripted) Name() string {
+	return p.name
+}
+
+// Played returns the number of moves of the script played so far.
+func (p *Scripted) Played() int {
+	return p.played
+}
+
+// Result returns whether the player was told that the game is over and the winner it was told.
+func (p *Scripted) Result() (bool, int) {
+	return p.gameOver, p.winner
+}
+
+// Turn is a call of a recorded player's Play function.
+type Turn struct {
+	Board [][]int // copy of the board the player was given
+	Side  int
+	Move  []int // move the player returned
+}
+
+// Recorder is a player.Player that records the turns and results of another player.
+type Recorder struct {
+	player.Player
+	Turns   []Turn
+	Winners []int // winner of every Done call in order
+}
+
+// NewRecorder returns a new recorder of p.
+func NewRecorder(p player.Player) *Recorder {
+	return &Recorder{Player: p}
+}
+
+// Play records the turn and returns the recorded player's move.
+func (r *Recorder) Play(board [][]int, side int) (int, int) {
+	cpy := make([][]int, len(board))
+	for i, row := range board {
+		cpy[i] = append([]int(nil), row...)
+	}
+	i, j := r.Player.Play(board, side)
+	r.Turns = append(r.Turns, Turn{Board: cpy, Side: side, Move: []int{i, j}})
+	return i, j
+}
+
+// Done records the winner and informs the recorded player.
+func (r *Recorder) Done(winner int) {
+	r.Winners = append(r.Winners, winner)
+	r.Player.Done(winner)
+}
+
+// format is the text format of Board and String: "-", "X", "O" and "#" with rows separated by '/',
+// for example "XO-/-X-/--O".
+var format = game.Format{Symbols: [4]string{"-", "X", "O", "#"}, RowSeparator: "/"}
+
+// lower maps the lower case letters and '.' that Board also accepts to the symbols of format.
+var lower = strings.NewReplacer("x", "X", "o", "O", ".", "-")
+
+// Board returns the board described by s, for example "XO-/-X-/--O": '-' is an empty position and
+// '#' a Blocked one. Rows are separated by '/' or new lines and spaces are ignored. Board also accepts
+// 'x' for X, 'o' for O and '.' for an empty position.
+func Board(s string) ([][]int, error) {
+	board, err := format.ParseBoard(lower.Replace(s))
+	if err != nil {
+		return nil, fmt.Errorf("gametest: board %q: %v", s, err)
+	}
+	return board, nil
+}
+
+// MustBoard is like Board but panics if s is not a valid board.
+func MustBoard(s string) [][]int {
+	board, err := Board(s)
+	if err != nil {
+		panic(err)
+	}
+	return board
+}
+
+// String returns the description of board in the format of Board with rows separated by '/'.
+func String(board [][]int) string {
+	return format.Render(board, nil)
+}
+
+// Run plays the game until it is over and returns the winner.
+func Run(g *game.TicTacToe) int {
+	for g.Play() {
+	}
+	_, winner := g.Result()
+	return winner
+}
+
+// AssertWinner fails the test unless the game is over and won by winner, 0 for a draw.
+func AssertWinner(t testing.TB, g *game.TicTacToe, winner int) {
+	t.Helper()
+	inProgress, got := g.Result()
+	if inProgress {
+		t.Fatalf("game is in progress, want winner %v\n%v", winner, g.Pretty())
+	}
+	if got != winner {
+		t.Fatalf("winner = %v, want %v\n%v", got, winner, g.Pretty())
+	}
+}
+
+// AssertDraw fails the test unless the game is over and drawn.
+func AssertDraw(t testing.TB, g *game.TicTacToe) {
+	t.Helper()
+	AssertWinner(t, g, 0)
+}
+
+// AssertInProgress fails the test if the game is over.
+func AssertInProgress(t testing.TB, g *game.TicTacToe) {
+	t.Helper()
+	if inProgress, winner := g.Result(); !inProgress {
+		t.Fatalf("game is over with winner %v, want in progress\n%v", winner, g.Pretty())
+	}
+}
+
+// AssertBoard fails the test unless the game's board is the board described by want.
+func AssertBoard(t testing.TB, g *game.TicTacToe, want string) {
+	t.Helper()
+	w, err := Board(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Board(); !reflect.DeepEqual(got, w) {
+		t.Fatalf("board = %v, want %v", String(got), String(w))
+	}
+}
@@ -0,0 +1,101 @@
+package gametest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func TestBoard(t *testing.T) {
+	tt := []struct {
+		name    string
+		s       string
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "slashes",
+			s:    "XO-/-X-/--O",
+			want: [][]int{{1, 2, 0}, {0, 1, 0}, {0, 0, 2}},
+		},
+		{
+			name: "lines and spaces",
+			s:    "\n x o . #\n . x . .\n",
+			want: [][]int{{1, 2, 0, game.Blocked}, {0, 1, 0, 0}},
+		},
+		{
+			name:    "ragged",
+			s:       "XO-/-X",
+			wantErr: true,
+		},
+		{
+			name:    "invalid position",
+			s:       "XO-/-Z-/---",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			s:       "/",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Board(tc.s)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Board() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Board() = %v, want %v", got, tc.want)
+			}
+			if err == nil {
+				if again := MustBoard(String(got)); !reflect.DeepEqual(again, got) {
+					t.Errorf("MustBoard(String()) = %v, want %v", again, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	x := NewScripted([][]int{{0, 0}, {1, 1}, {2, 2}}, "X")
+	o := NewRecorder(NewScripted([][]int{{0, 1}, {0, 2}}, "O"))
+	g, err := game.New(e, x, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Play()
+	AssertInProgress(t, g)
+	if Run(g) != 1 {
+		t.Errorf("Run() != 1")
+	}
+	AssertWinner(t, g, 1)
+	AssertBoard(t, g, "XOO/-X-/--X")
+
+	if x.Played() != 3 {
+		t.Errorf("Scripted.Played() = %v, want 3", x.Played())
+	}
+	if over, winner := x.Result(); !over || winner != 1 {
+		t.Errorf("Scripted.Result() = %v, %v, want true, 1", over, winner)
+	}
+	want := []Turn{
+		{Board: MustBoard("X--/---/---"), Side: 2, Move: []int{0, 1}},
+		{Board: MustBoard("XO-/-X-/---"), Side: 2, Move: []int{0, 2}},
+	}
+	if !reflect.DeepEqual(o.Turns, want) {
+		t.Errorf("Recorder.Turns = %v, want %v", o.Turns, want)
+	}
+	if !reflect.DeepEqual(o.Winners, []int{1}) || o.Name() != "O" {
+		t.Errorf("Recorder.Winners = %v, Recorder.Name() = %v", o.Winners, o.Name())
+	}
+}
+
+func TestScripted_Forfeit(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	g, _ := game.New(e, NewScripted([][]int{{1, 1}}, "X"), NewScripted(nil, "O"))
+	Run(g)
+	AssertWinner(t, g, 1)
+	AssertBoard(t, g, "---/-X-/---")
+}
@@ -0,0 +1,72 @@
+package game
+
+// Heuristic scores positions for search based players that can not search until the end of the game.
+type Heuristic interface {
+	// Score returns the score of board from side's point of view, higher is better for side.
+	Score(board [][]int, side int) int
+}
+
+// WindowHeuristic is the default Heuristic. It looks at every window of target consecutive positions
+// in every line direction, and scores each window that contains only one side's symbols by the number
+// of symbols in it. Windows that contain both sides' symbols or blocked positions can not become a line
+// and are ignored.
+type WindowHeuristic struct {
+	e       *Engine
+	weights []int
+}
+
+// NewWindowHeuristic returns a new WindowHeuristic for the engine's board.
+// weights[n] is the score of a window with n symbols of a side, so weights must have target+1 entries.
+// nil weights use DefaultWeights.
+func NewWindowHeuristic(e *Engine, weights []int) (*WindowHeuristic, error) {
+	if e == nil {
+		return nil, ErrInvalidGameSpecs
+	}
+	if weights == nil {
+		weights = DefaultWeights(e.target)
+	}
+	if len(weights) != e.target+1 {
+		return nil, ErrInvalidWeights
+	}
+	w := make([]int, len(weights))
+	copy(w, weights)
+	return &WindowHeuristic{e: e, weights: w}, nil
+}
+
+// DefaultWeights returns weights that grow tenfold with every symbol in a window,
+// so that a single window closer to a line outweighs many windows with fewer symbols.
+func DefaultWeights(target int) []int {
+	weights := make([]int, target+1)
+	w := 1
+	for n := 1; n <= target; n++ {
+		weights[n] = w
+		// stop growing instead of overflowing on very large targets
+		if next := w * 10; next/10 == w {
+			w = next
+		}
+	}
+	return weights
+}
+
+// Score returns the sum of side's window scores minus the sum of the opponent's window scores.
+// It returns 0 if board does not match the engine's size or side is invalid.
+func (h *WindowHeuristic) Score(board [][]int, side int) int {
+	e := h.e
+	if !e.fits(board) || (side != 1 && side != 2) {
+		return 0
+	}
+	score := 0
+	for i := 0; i < e.rows; i++ {
+		for j := 0; j < e.columns; j++ {
+			for _, d := range directions2D {
+				if _, own, ok := e.window(board, side, i, j, d, e.target); ok {
+					score += h.weights[own]
+				}
+				if _, other, ok := e.window(board, 3-side, i, j, d, e.target); ok {
+					score -= h.weights[other]
+				}
+			}
+		}
+	}
+	return score
+}
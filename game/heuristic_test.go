@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewWindowHeuristic(t *testing.T) {
+	e, _ := NewEngine(4, 4, 3)
+	if _, err := NewWindowHeuristic(e, nil); err != nil {
+		t.Errorf("NewWindowHeuristic() error = %v, want nil", err)
+	}
+	if _, err := NewWindowHeuristic(e, []int{0, 1, 2}); err == nil {
+		t.Errorf("NewWindowHeuristic() with too few weights error = nil, want error")
+	}
+	if _, err := NewWindowHeuristic(nil, nil); err == nil {
+		t.Errorf("NewWindowHeuristic() with nil engine error = nil, want error")
+	}
+}
+
+func TestWindowHeuristic_Score(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	h, _ := NewWindowHeuristic(e, []int{0, 1, 10, 100})
+	tests := []struct {
+		name  string
+		board [][]int
+		side  int
+		want  int
+	}{
+		{
+			name: "empty board",
+			board: [][]int{
+				[]int{0, 0, 0},
+				[]int{0, 0, 0},
+				[]int{0, 0, 0},
+			},
+			side: 1,
+			want: 0,
+		},
+		{
+			name: "X in the center is in every line",
+			board: [][]int{
+				[]int{0, 0, 0},
+				[]int{0, 1, 0},
+				[]int{0, 0, 0},
+			},
+			side: 1,
+			want: 4,
+		},
+		{
+			name: "X in the center from O's point of view",
+			board: [][]int{
+				[]int{0, 0, 0},
+				[]int{0, 1, 0},
+				[]int{0, 0, 0},
+			},
+			side: 2,
+			want: -4,
+		},
+		{
+			name: "X two in a row, O in the corner",
+			board: [][]int{
+				[]int{1, 1, 0},
+				[]int{0, 0, 0},
+				[]int{0, 0, 2},
+			},
+			side: 1,
+			// X: top row 10, first column 1, second column 1; diagonal is shared with O
+			// O: last row 1, last column 1
+			want: 10,
+		},
+		{
+			name: "blocked positions break windows",
+			board: [][]int{
+				[]int{1, 3, 0},
+				[]int{0, 3, 0},
+				[]int{0, 0, 3},
+			},
+			side: 1,
+			want: 1,
+		},
+		{
+			name: "board of the wrong size",
+			board: [][]int{
+				[]int{1, 1},
+				[]int{0, 0},
+			},
+			side: 1,
+			want: 0,
+		},
+		{
+			name: "invalid side",
+			board: [][]int{
+				[]int{0, 0, 0},
+				[]int{0, 1, 0},
+				[]int{0, 0, 0},
+			},
+			side: 3,
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Score(tt.board, tt.side); got != tt.want {
+				t.Errorf("WindowHeuristic.Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+// directions2D holds one vector for each of the 4 line directions of a board: horizontal, vertical,
+// upper left to lower right and upper right to lower left. The opposite directions are covered by
+// walking each vector both ways.
+var directions2D = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// Directions returns the row and column steps of the 4 line directions of a board in the order
+// the engine checks them.
+func Directions() [4][2]int {
+	return directions2D
+}
+
+// Line is a line of consecutive symbols of a side, such as the line that wins a game.
+type Line struct {
+	Side      int
+	Start     []int   // first position of the line, the topmost, or the leftmost of a horizontal line
+	End       []int   // last position of the line
+	Direction [2]int  // row and column step from one position of the line to the next
+	Cells     [][]int // every position of the line from Start to End
+}
+
+// newLine returns the line of side of n positions starting at i, j in direction d.
+func newLine(side, i, j int, d [2]int, n int) *Line {
+	l := &Line{Side: side, Direction: d}
+	for k := 0; k < n; k++ {
+		l.Cells = append(l.Cells, []int{i + k*d[0], j + k*d[1]})
+	}
+	l.Start, l.End = l.Cells[0], l.Cells[n-1]
+	return l
+}
+
+// Len returns the number of positions of the line.
+func (l *Line) Len() int {
+	return len(l.Cells)
+}
+
+// WinningLine returns a line of target or more consecutive X's or O's on board, or nil if there is none.
+// When there is more than one line, the line of X that starts first in row major order is returned,
+// or the first line of O if X has none.
+func (e *Engine) WinningLine(board [][]int) *Line {
+	if !e.fits(board) {
+		return nil
+	}
+	for side := 1; side <= 2; side++ {
+		if l := e.findLine(board, side); l != nil {
+			return l
+		}
+	}
+	return nil
+}
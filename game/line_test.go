@@ -0,0 +1,164 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEngine_EvaluateLine(t *testing.T) {
+	e, _ := NewEngine(5, 5, 3)
+	tt := []struct {
+		name  string
+		board [][]int
+		side  int
+		i, j  int
+		want  *Line
+	}{
+		{
+			name: "vertical",
+			board: [][]int{
+				{0, 1, 0, 0, 0},
+				{0, 1, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 2, 2},
+				{0, 0, 0, 0, 0},
+			},
+			side: 1, i: 2, j: 1,
+			want: &Line{Side: 1, Start: []int{0, 1}, End: []int{2, 1}, Direction: [2]int{1, 0},
+				Cells: [][]int{{0, 1}, {1, 1}, {2, 1}}},
+		},
+		{
+			name: "horizontal joining two lines",
+			board: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{2, 2, 0, 2, 2},
+				{1, 1, 0, 1, 0},
+				{0, 0, 0, 0, 1},
+			},
+			side: 2, i: 2, j: 2,
+			want: &Line{Side: 2, Start: []int{2, 0}, End: []int{2, 4}, Direction: [2]int{0, 1},
+				Cells: [][]int{{2, 0}, {2, 1}, {2, 2}, {2, 3}, {2, 4}}},
+		},
+		{
+			name: "upper left to lower right",
+			board: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 1, 0},
+				{2, 2, 0, 0, 2},
+			},
+			side: 1, i: 2, j: 2,
+			want: &Line{Side: 1, Start: []int{1, 1}, End: []int{3, 3}, Direction: [2]int{1, 1},
+				Cells: [][]int{{1, 1}, {2, 2}, {3, 3}}},
+		},
+		{
+			name: "upper right to lower left",
+			board: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 1},
+				{0, 0, 0, 1, 0},
+				{2, 2, 0, 0, 2},
+			},
+			side: 1, i: 4, j: 2,
+			want: &Line{Side: 1, Start: []int{2, 4}, End: []int{4, 2}, Direction: [2]int{1, -1},
+				Cells: [][]int{{2, 4}, {3, 3}, {4, 2}}},
+		},
+		{
+			name: "no line",
+			board: [][]int{
+				{1, 1, 0, 0, 0},
+				{2, 2, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+			},
+			side: 1, i: 2, j: 2,
+		},
+		{
+			name: "illegal move",
+			board: [][]int{
+				{1, 1, 0, 0, 0},
+				{2, 2, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+			},
+			side: 1, i: 1, j: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, winner, line, err := e.EvaluateLine(tc.board, tc.side, tc.i, tc.j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(line, tc.want) {
+				t.Errorf("Engine.EvaluateLine() line = %+v, want %+v", line, tc.want)
+			}
+			if line != nil && (winner != tc.side || line.Len() < e.Target()) {
+				t.Errorf("Engine.EvaluateLine() winner = %v, line length %v", winner, line.Len())
+			}
+		})
+	}
+}
+
+func TestEngine_WinningLine(t *testing.T) {
+	e, _ := NewEngine(3, 4, 3)
+	tt := []struct {
+		name  string
+		board [][]int
+		want  *Line
+	}{
+		{
+			name:  "O line",
+			board: [][]int{{1, 1, 0, 2}, {1, 0, 2, 0}, {0, 2, 0, 0}},
+			want: &Line{Side: 2, Start: []int{0, 3}, End: []int{2, 1}, Direction: [2]int{1, -1},
+				Cells: [][]int{{0, 3}, {1, 2}, {2, 1}}},
+		},
+		{
+			name:  "blocked",
+			board: [][]int{{1, Blocked, 1, 1}, {2, 2, 0, 0}, {0, 0, 0, 0}},
+		},
+		{
+			name:  "wrong size",
+			board: [][]int{{1, 1, 1}, {0, 0, 0}, {0, 0, 0}},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := e.WinningLine(tc.board); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Engine.WinningLine() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicTacToe_WinningLine(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	g, _ := New(e, NewTestPlayer([][]int{{0, 0}, {1, 1}, {2, 2}}, "X"), NewTestPlayer([][]int{{0, 1}, {0, 2}}, "O"))
+	if g.WinningLine() != nil {
+		t.Error("TicTacToe.WinningLine() of a new game is not nil")
+	}
+	for g.Play() {
+	}
+	want := &Line{Side: 1, Start: []int{0, 0}, End: []int{2, 2}, Direction: [2]int{1, 1},
+		Cells: [][]int{{0, 0}, {1, 1}, {2, 2}}}
+	got := g.WinningLine()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TicTacToe.WinningLine() = %+v, want %+v", got, want)
+	}
+	got.Cells[0][0] = 2
+	if !reflect.DeepEqual(g.WinningLine(), want) {
+		t.Error("TicTacToe.WinningLine() returns the game's line instead of a copy")
+	}
+
+	g, _ = New(e, NewTestPlayer([][]int{{0, 0}}, "X"), NewTestPlayer([][]int{{0, 0}}, "O"))
+	for g.Play() {
+	}
+	if _, winner := g.Result(); winner != 1 || g.WinningLine() != nil {
+		t.Errorf("TicTacToe.WinningLine() after an illegal move = %+v, winner %v", g.WinningLine(), winner)
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+// LegalMoves returns every unoccupied position of board in row major order.
+// It returns nil if board does not match the engine's size.
+func (e *Engine) LegalMoves(board [][]int) [][]int {
+	if !e.fits(board) {
+		return nil
+	}
+	var moves [][]int
+	for i, row := range board {
+		for j, v := range row {
+			if v == 0 {
+				moves = append(moves, []int{i, j})
+			}
+		}
+	}
+	return moves
+}
+
+// Legal returns whether a move to i, j is legal on board, that is, whether i, j is an unoccupied
+// position of the board. Unlike Evaluate, an illegal move is only reported and not forfeited.
+func (e *Engine) Legal(board [][]int, i, j int) bool {
+	return i >= 0 && j >= 0 && i < e.rows && j < e.columns && e.fits(board) && board[i][j] == 0
+}
+
+// WinningMoves returns the positions where side's move completes a line and wins immediately.
+// It returns nil if board does not match the engine's size or side is invalid.
+func (e *Engine) WinningMoves(board [][]int, side int) [][]int {
+	if !e.fits(board) || (side != 1 && side != 2) {
+		return nil
+	}
+	var moves [][]int
+	for i, row := range board {
+		for j, v := range row {
+			if v == 0 && e.completes(board, side, i, j) {
+				moves = append(moves, []int{i, j})
+			}
+		}
+	}
+	return moves
+}
+
+// BlockingMoves returns the positions side must occupy to stop the opponent from winning immediately.
+// More than one blocking move means side can not stop the opponent with a single move.
+func (e *Engine) BlockingMoves(board [][]int, side int) [][]int {
+	if side != 1 && side != 2 {
+		return nil
+	}
+	return e.WinningMoves(board, 3-side)
+}
+
+// fits returns whether board matches the engine's size.
+func (e *Engine) fits(board [][]int) bool {
+	if len(board) != e.rows {
+		return false
+	}
+	for _, row := range board {
+		if len(row) != e.columns {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEngine_Moves(t *testing.T) {
+	e, _ := NewEngine(3, 4, 3)
+	board := [][]int{
+		[]int{1, 1, 0, 2},
+		[]int{2, 3, 0, 1},
+		[]int{0, 0, 2, 1},
+	}
+	tests := []struct {
+		name string
+		got  [][]int
+		want [][]int
+	}{
+		{
+			name: "legal moves skip occupied and blocked positions",
+			got:  e.LegalMoves(board),
+			want: [][]int{[]int{0, 2}, []int{1, 2}, []int{2, 0}, []int{2, 1}},
+		},
+		{
+			name: "X wins in the top row and on the diagonal",
+			got:  e.WinningMoves(board, 1),
+			want: [][]int{[]int{0, 2}, []int{1, 2}},
+		},
+		{
+			name: "O wins nowhere",
+			got:  e.WinningMoves(board, 2),
+			want: nil,
+		},
+		{
+			name: "O must block both of X's wins",
+			got:  e.BlockingMoves(board, 2),
+			want: [][]int{[]int{0, 2}, []int{1, 2}},
+		},
+		{
+			name: "board of the wrong size",
+			got:  e.LegalMoves([][]int{[]int{0, 0, 0}}),
+			want: nil,
+		},
+		{
+			name: "invalid side",
+			got:  e.WinningMoves(board, 3),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_Legal(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	board := [][]int{
+		[]int{1, 0, 0},
+		[]int{0, 3, 0},
+		[]int{0, 0, 0},
+	}
+	tests := []struct {
+		name string
+		i, j int
+		want bool
+	}{
+		{name: "unoccupied", i: 0, j: 1, want: true},
+		{name: "occupied", i: 0, j: 0, want: false},
+		{name: "blocked", i: 1, j: 1, want: false},
+		{name: "outside", i: 3, j: 0, want: false},
+		{name: "negative", i: 0, j: -1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Legal(board, tt.i, tt.j); got != tt.want {
+				t.Errorf("Engine.Legal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
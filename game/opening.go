@@ -0,0 +1,151 @@
+package game
+
+import "github.com/mraufc/tictactoe/player"
+
+// Opening is an opening protocol that decides how the first moves of a game are made.
+// Openings other than OpeningNone and OpeningCenter make the first player's advantage
+// something the second player can take away, so both players must implement player.OpeningPlayer.
+type Opening int
+
+const (
+	// OpeningNone lets X make the first move anywhere on the board.
+	OpeningNone Opening = iota
+	// OpeningCenter restricts X's first move to the center of the board.
+	// On an even number of rows or columns either of the two middle lines is center.
+	OpeningCenter
+	// OpeningPie lets the second player swap sides after X's first move.
+	OpeningPie
+	// OpeningSwap lets the first player place two X's and one O, after which the second player chooses a side.
+	OpeningSwap
+	// OpeningSwap2 lets the first player place two X's and one O, after which the second player either
+	// chooses a side or places one more O and one more X and lets the first player choose a side.
+	OpeningSwap2
+)
+
+// SetOpening sets the opening protocol of the game. It must be called before the first move.
+// Swap based openings are only available when every turn consists of a single placement.
+func (t *TicTacToe) SetOpening(o Opening) error {
+	if t.moves > 0 || o < OpeningNone || o > OpeningSwap2 {
+		return ErrInvalidGameSpecs
+	}
+	if o >= OpeningPie {
+		if t.stones > 1 || t.firstStones > 1 {
+			return ErrInvalidGameSpecs
+		}
+		if _, ok := t.player1.(player.OpeningPlayer); !ok {
+			return ErrInvalidGameSpecs
+		}
+		if _, ok := t.player2.(player.OpeningPlayer); !ok {
+			return ErrInvalidGameSpecs
+		}
+	}
+	t.opening = o
+	return nil
+}
+
+// playOpening runs the part of the opening protocol that is due before the next regular move.
+// It returns true if the opening consumed the current call to Play.
+func (t *TicTacToe) playOpening() bool {
+	switch t.opening {
+	case OpeningPie:
+		if t.moves != 1 {
+			return false
+		}
+		t.opened = true
+		switch t.choose(t.player2.(player.OpeningPlayer), 2, []int{1, 2}) {
+		case 1:
+			t.swapPlayers()
+		case 2:
+		default:
+			t.forfeit(2, nil)
+			return true
+		}
+		return false
+	case OpeningSwap, OpeningSwap2:
+		t.opened = true
+		first := t.player1.(player.OpeningPlayer)
+		second := t.player2.(player.OpeningPlayer)
+		if t.place(first, []int{1, 2, 1}, 2) {
+			return true
+		}
+		options := []int{1, 2}
+		if t.opening == OpeningSwap2 {
+			options = []int{0, 1, 2}
+		}
+		switch t.choose(second, 2, options) {
+		case 1:
+			t.swapPlayers()
+		case 2:
+		case 0:
+			if t.opening != OpeningSwap2 {
+				t.forfeit(2, nil)
+				return true
+			}
+			if t.place(second, []int{2, 1}, 1) {
+				return true
+			}
+			switch t.choose(first, 1, []int{1, 2}) {
+			case 1:
+			case 2:
+				t.swapPlayers()
+			default:
+				t.forfeit(1, nil)
+			}
+		default:
+			t.forfeit(2, nil)
+		}
+		return true
+	}
+	t.opened = true
+	return false
+}
+
+// choose asks p, the player of side, to choose one of options and reports the choice to the observers.
+func (t *TicTacToe) choose(p player.OpeningPlayer, side int, options []int) int {
+	choice := p.Choose(CopyBoard(t.board), options)
+	t.emit(Event{Type: EventChoice, Side: side, Choice: choice})
+	return choice
+}
+
+// place asks p to place stones of the given sides and evaluates each placement.
+// An illegal placement ends the game with winnerOnIllegal as the winner.
+// It returns true if the game is over.
+func (t *TicTacToe) place(p player.OpeningPlayer, sides []int, winnerOnIllegal int) bool {
+	t.emit(Event{Type: EventMoveRequested, Side: 3 - winnerOnIllegal, Count: len(sides)})
+	positions := p.Place(CopyBoard(t.board), sides)
+	if len(positions) != len(sides) {
+		t.forfeit(3-winnerOnIllegal, nil)
+		return true
+	}
+	for k, side := range sides {
+		pos := positions[k]
+		if len(pos) != 2 || !t.e.Legal(t.board, pos[0], pos[1]) {
+			var move []int
+			if len(pos) == 2 {
+				move = []int{pos[0], pos[1]}
+			}
+			t.forfeit(3-winnerOnIllegal, move)
+			return true
+		}
+		gameOver, winner, line := t.e.evaluateLine(t.board, side, pos[0], pos[1], t.unoccupied())
+		t.board[pos[0]][pos[1]] = side
+		t.moves++
+		t.history = append(t.history, []int{pos[0], pos[1]})
+		t.emit(Event{Type: EventMovePlayed, Side: side, Move: []int{pos[0], pos[1]}})
+		if gameOver {
+			t.line = line
+			t.finish(winner)
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TicTacToe) swapPlayers() {
+	t.player1, t.player2 = t.player2, t.player1
+}
+
+// center returns whether i, j is a center position of the board.
+func (e *Engine) center(i, j int) bool {
+	return (i == (e.rows-1)/2 || i == e.rows/2) && (j == (e.columns-1)/2 || j == e.columns/2)
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestTicTacToe_SetOpening(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	plain, _ := New(e, NewTestPlayer(nil, "X"), NewTestPlayer(nil, "O"))
+	if err := plain.SetOpening(OpeningCenter); err != nil {
+		t.Errorf("TicTacToe.SetOpening(OpeningCenter) error = %v, want nil", err)
+	}
+	if err := plain.SetOpening(OpeningSwap); err == nil {
+		t.Errorf("TicTacToe.SetOpening(OpeningSwap) with plain players error = nil, want error")
+	}
+	opening, _ := New(e, &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "X")}, &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "O")})
+	if err := opening.SetOpening(OpeningSwap2); err != nil {
+		t.Errorf("TicTacToe.SetOpening(OpeningSwap2) error = %v, want nil", err)
+	}
+	if err := opening.SetOpening(Opening(42)); err == nil {
+		t.Errorf("TicTacToe.SetOpening(42) error = nil, want error")
+	}
+}
+
+func TestTicTacToe_PlayOpening(t *testing.T) {
+	type want struct {
+		inProgress bool
+		winner     int
+		moves      int
+		player1    string
+	}
+	tests := []struct {
+		name    string
+		opening Opening
+		p1      *testOpeningPlayer
+		p2      *testOpeningPlayer
+		plays   int
+		want    want
+	}{
+		{
+			name:    "center, X plays the center",
+			opening: OpeningCenter,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer([][]int{[]int{1, 1}}, "p1")},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2")},
+			plays:   1,
+			want:    want{inProgress: true, winner: 0, moves: 1, player1: "p1"},
+		},
+		{
+			name:    "center, X plays a corner and loses",
+			opening: OpeningCenter,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer([][]int{[]int{0, 0}}, "p1")},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2")},
+			plays:   1,
+			want:    want{inProgress: false, winner: 2, moves: 0, player1: "p1"},
+		},
+		{
+			name:    "pie, second player swaps",
+			opening: OpeningPie,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer([][]int{[]int{1, 1}, []int{0, 0}}, "p1")},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2"), choices: []int{1}},
+			plays:   2,
+			want:    want{inProgress: true, winner: 0, moves: 2, player1: "p2"},
+		},
+		{
+			name:    "swap, second player keeps O",
+			opening: OpeningSwap,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p1"), places: [][]int{[]int{0, 0}, []int{1, 1}, []int{2, 2}}},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2"), choices: []int{2}},
+			plays:   1,
+			want:    want{inProgress: true, winner: 0, moves: 3, player1: "p1"},
+		},
+		{
+			name:    "swap, first player places on an occupied position",
+			opening: OpeningSwap,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p1"), places: [][]int{[]int{0, 0}, []int{0, 0}, []int{2, 2}}},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2")},
+			plays:   1,
+			want:    want{inProgress: false, winner: 2, moves: 1, player1: "p1"},
+		},
+		{
+			name:    "swap2, second player places two more and first player takes O",
+			opening: OpeningSwap2,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p1"), places: [][]int{[]int{0, 0}, []int{1, 1}, []int{2, 2}}, choices: []int{2}},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2"), places: [][]int{[]int{0, 1}, []int{0, 2}}, choices: []int{0}},
+			plays:   1,
+			want:    want{inProgress: true, winner: 0, moves: 5, player1: "p2"},
+		},
+		{
+			name:    "swap2, invalid choice loses",
+			opening: OpeningSwap2,
+			p1:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p1"), places: [][]int{[]int{0, 0}, []int{1, 1}, []int{2, 2}}},
+			p2:      &testOpeningPlayer{TestPlayer: NewTestPlayer(nil, "p2"), choices: []int{5}},
+			plays:   1,
+			want:    want{inProgress: false, winner: 1, moves: 3, player1: "p1"},
+		},
+	}
+	e, _ := NewEngine(3, 3, 3)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(e, tt.p1, tt.p2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.SetOpening(tt.opening); err != nil {
+				t.Fatal(err)
+			}
+			for k := 0; k < tt.plays; k++ {
+				g.Play()
+			}
+			inProgress, winner := g.Result()
+			if inProgress != tt.want.inProgress || winner != tt.want.winner {
+				t.Errorf("TicTacToe.Result() = %v, %v, want %v, %v", inProgress, winner, tt.want.inProgress, tt.want.winner)
+			}
+			if g.moves != tt.want.moves {
+				t.Errorf("TicTacToe.Play() moves = %v, want %v", g.moves, tt.want.moves)
+			}
+			if g.player1.Name() != tt.want.player1 {
+				t.Errorf("TicTacToe.Play() player1 = %v, want %v", g.player1.Name(), tt.want.player1)
+			}
+		})
+	}
+}
+
+// testOpeningPlayer implements OpeningPlayer interface
+type testOpeningPlayer struct {
+	*TestPlayer
+	places  [][]int
+	choices []int
+}
+
+func (tp *testOpeningPlayer) Place(board [][]int, sides []int) [][]int {
+	n := len(sides)
+	if n > len(tp.places) {
+		n = len(tp.places)
+	}
+	positions := tp.places[:n]
+	tp.places = tp.places[n:]
+	return positions
+}
+
+func (tp *testOpeningPlayer) Choose(board [][]int, options []int) int {
+	if len(tp.choices) == 0 {
+		return -1
+	}
+	choice := tp.choices[0]
+	tp.choices = tp.choices[1:]
+	return choice
+}
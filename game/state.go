@@ -0,0 +1,157 @@
+package game
+
+// State is a game position built on an Engine's rules: the board, the side to move, the number of moves
+// made and the outcome. Unlike TicTacToe, it has no players, which makes it suitable for search and analysis.
+//
+// Apply returns a new State and leaves the receiver untouched. Do and Undo modify the State in place,
+// which is much cheaper in a search tree. A State copied by assignment shares its board with the
+// original, so use Clone before modifying a copy in place.
+type State struct {
+	e        *Engine
+	board    [][]int // 0 -> empty position, 1 -> X, 2 -> O, 3 -> Blocked
+	side     int
+	moves    int
+	empty    int     // number of unoccupied positions
+	history  [][]int // moves made with Do that can be undone
+	gameOver bool
+	winner   int
+	z        *Zobrist
+	hash     uint64
+}
+
+// NewState returns the state of board, which is validated by the engine. A nil board is an empty board.
+// Unlike Validate, NewState accepts positions where the game is over and reports their outcome with Result.
+func NewState(e *Engine, board [][]int) (*State, error) {
+	if e == nil {
+		return nil, ErrInvalidGameSpecs
+	}
+	if board == nil {
+		board = make([][]int, e.rows)
+		for i := range board {
+			board[i] = make([]int, e.columns)
+		}
+	}
+	side, gameOver, winner, err := e.outcome(board)
+	if err != nil {
+		return nil, err
+	}
+	z := NewZobrist(e)
+	s := &State{
+		e:        e,
+		board:    CopyBoard(board),
+		side:     side,
+		gameOver: gameOver,
+		winner:   winner,
+		z:        z,
+		hash:     z.Hash(board, side),
+	}
+	for _, row := range board {
+		for _, v := range row {
+			switch v {
+			case 0:
+				s.empty++
+			case 1, 2:
+				s.moves++
+			}
+		}
+	}
+	return s, nil
+}
+
+// Engine returns the engine of the state.
+func (s *State) Engine() *Engine {
+	return s.e
+}
+
+// Board returns a copy of the board.
+func (s *State) Board() [][]int {
+	return CopyBoard(s.board)
+}
+
+// Side returns the side to move, 1 for X and 2 for O.
+func (s *State) Side() int {
+	return s.side
+}
+
+// Moves returns the number of X's and O's on the board.
+func (s *State) Moves() int {
+	return s.moves
+}
+
+// Result returns if the game is still in progress and the winner
+func (s *State) Result() (bool, int) {
+	return !s.gameOver, s.winner
+}
+
+// Hash returns the Zobrist hash of the position and the side to move.
+// It is updated incrementally by Do and Undo.
+func (s *State) Hash() uint64 {
+	return s.hash
+}
+
+// Zobrist returns the Zobrist keys the state is hashed with.
+func (s *State) Zobrist() *Zobrist {
+	return s.z
+}
+
+// LegalMoves returns the legal moves of the side to move, nil if the game is over.
+func (s *State) LegalMoves() [][]int {
+	if s.gameOver {
+		return nil
+	}
+	return s.e.LegalMoves(s.board)
+}
+
+// Clone returns a deep copy of the state.
+func (s *State) Clone() *State {
+	c := *s
+	c.board = CopyBoard(s.board)
+	c.history = make([][]int, len(s.history))
+	copy(c.history, s.history)
+	return &c
+}
+
+// Apply returns the state after the side to move plays i, j. The receiver is not modified.
+func (s *State) Apply(i, j int) (*State, error) {
+	c := s.Clone()
+	if err := c.Do(i, j); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Do plays i, j for the side to move in place.
+// Unlike TicTacToe, an illegal move is not forfeited but returns ErrIllegalMove and leaves the state as is.
+func (s *State) Do(i, j int) error {
+	if s.gameOver {
+		return ErrGameOver
+	}
+	if !s.e.Legal(s.board, i, j) {
+		return ErrIllegalMove
+	}
+	s.gameOver, s.winner = s.e.evaluate(s.board, s.side, i, j, s.empty)
+	s.board[i][j] = s.side
+	s.hash = s.z.ToggleSide(s.z.Toggle(s.hash, i, j, s.side))
+	s.side = 3 - s.side
+	s.moves++
+	s.empty--
+	s.history = append(s.history, []int{i, j})
+	return nil
+}
+
+// Undo takes back the last move made with Do or Apply.
+func (s *State) Undo() error {
+	if len(s.history) == 0 {
+		return ErrNoMoves
+	}
+	m := s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+	s.side = 3 - s.side
+	s.hash = s.z.ToggleSide(s.z.Toggle(s.hash, m[0], m[1], s.side))
+	s.board[m[0]][m[1]] = 0
+	s.moves--
+	s.empty++
+	// moves can only be made while the game is in progress
+	s.gameOver, s.winner = false, 0
+	return nil
+}
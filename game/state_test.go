@@ -0,0 +1,151 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewState(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	tests := []struct {
+		name           string
+		board          [][]int
+		wantSide       int
+		wantInProgress bool
+		wantWinner     int
+		wantErr        error
+	}{
+		{
+			name:           "in progress",
+			board:          [][]int{[]int{1, 1, 0}, []int{2, 2, 0}, []int{0, 0, 0}},
+			wantSide:       1,
+			wantInProgress: true,
+		},
+		{
+			name:       "won by X",
+			board:      [][]int{[]int{1, 1, 1}, []int{2, 2, 0}, []int{0, 0, 0}},
+			wantSide:   2,
+			wantWinner: 1,
+		},
+		{
+			name:       "won by O",
+			board:      [][]int{[]int{1, 1, 0}, []int{2, 2, 2}, []int{1, 0, 0}},
+			wantSide:   1,
+			wantWinner: 2,
+		},
+		{
+			name:     "full board drawn",
+			board:    [][]int{[]int{1, 2, 1}, []int{1, 2, 2}, []int{2, 1, 1}},
+			wantSide: 2,
+		},
+		{
+			name:    "X won but O moved last",
+			board:   [][]int{[]int{1, 1, 1}, []int{2, 2, 0}, []int{2, 0, 0}},
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "both sides won",
+			board:   [][]int{[]int{1, 1, 1}, []int{2, 2, 2}, []int{1, 0, 0}},
+			wantErr: ErrInvalidBoard,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewState(e, tt.board)
+			if err != tt.wantErr {
+				t.Fatalf("NewState() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if s.Side() != tt.wantSide {
+				t.Errorf("State.Side() = %v, want %v", s.Side(), tt.wantSide)
+			}
+			if inProgress, winner := s.Result(); inProgress != tt.wantInProgress || winner != tt.wantWinner {
+				t.Errorf("State.Result() = %v, %v, want %v, %v", inProgress, winner, tt.wantInProgress, tt.wantWinner)
+			}
+			if !tt.wantInProgress {
+				if _, err := s.Apply(0, 0); err != ErrGameOver {
+					t.Errorf("State.Apply() error = %v, want %v", err, ErrGameOver)
+				}
+			}
+		})
+	}
+}
+
+func TestState_Apply(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	s, err := NewState(e, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := [][]int{[]int{1, 1}, []int{0, 0}, []int{0, 2}, []int{1, 0}, []int{2, 2}, []int{2, 0}}
+	states := []*State{s}
+	for _, m := range moves {
+		next, err := states[len(states)-1].Apply(m[0], m[1])
+		if err != nil {
+			t.Fatalf("State.Apply(%v) error = %v", m, err)
+		}
+		states = append(states, next)
+	}
+	// earlier states are not modified
+	for k, st := range states {
+		if st.Moves() != k {
+			t.Errorf("state %v Moves() = %v, want %v", k, st.Moves(), k)
+		}
+		want := 9 - k
+		if inProgress, _ := st.Result(); !inProgress {
+			want = 0
+		}
+		if got := len(st.LegalMoves()); got != want {
+			t.Errorf("state %v has %v legal moves, want %v", k, got, want)
+		}
+	}
+	last := states[len(states)-1]
+	if inProgress, winner := last.Result(); inProgress || winner != 2 {
+		t.Errorf("State.Result() = %v, %v, want false, 2", inProgress, winner)
+	}
+	if _, err := last.Apply(2, 2); err != ErrGameOver {
+		t.Errorf("State.Apply() after game over error = %v, want %v", err, ErrGameOver)
+	}
+	if _, err := states[1].Apply(1, 1); err != ErrIllegalMove {
+		t.Errorf("State.Apply() to occupied position error = %v, want %v", err, ErrIllegalMove)
+	}
+}
+
+func TestState_DoUndo(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	board := [][]int{
+		[]int{1, 1, 0},
+		[]int{2, 2, 0},
+		[]int{0, 0, 0},
+	}
+	s, err := NewState(e, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := s.Clone()
+	if err := s.Do(0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if inProgress, winner := s.Result(); inProgress || winner != 1 {
+		t.Errorf("State.Result() = %v, %v, want false, 1", inProgress, winner)
+	}
+	if err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, before) {
+		t.Errorf("State.Undo() = %v, want %v", s, before)
+	}
+	if err := s.Undo(); err != ErrNoMoves {
+		t.Errorf("State.Undo() without moves error = %v, want %v", err, ErrNoMoves)
+	}
+	if s.Side() != 1 {
+		t.Errorf("State.Side() = %v, want 1", s.Side())
+	}
+	// Board returns a copy
+	s.Board()[0][2] = 2
+	if s.board[0][2] != 0 {
+		t.Errorf("State.Board() did not return a copy")
+	}
+}
@@ -0,0 +1,165 @@
+package game
+
+import "reflect"
+
+// Symmetry is a rotation or reflection of the board that maps lines to lines,
+// so positions that are symmetric to each other have the same game theoretic value.
+type Symmetry int
+
+const (
+	// Identity leaves the board as is.
+	Identity Symmetry = iota
+	// Rotate180 rotates the board by 180 degrees.
+	Rotate180
+	// FlipRows reverses the order of rows.
+	FlipRows
+	// FlipColumns reverses the order of columns.
+	FlipColumns
+	// Rotate90 rotates a square board clockwise by 90 degrees.
+	Rotate90
+	// Rotate270 rotates a square board clockwise by 270 degrees.
+	Rotate270
+	// Transpose reflects a square board along its main diagonal.
+	Transpose
+	// AntiTranspose reflects a square board along its anti diagonal.
+	AntiTranspose
+)
+
+// Symmetries returns the symmetries of a rows by columns board.
+// Square boards have 8 symmetries while other boards only have the first 4.
+func Symmetries(rows, columns int) []Symmetry {
+	if rows == columns {
+		return []Symmetry{Identity, Rotate180, FlipRows, FlipColumns, Rotate90, Rotate270, Transpose, AntiTranspose}
+	}
+	return []Symmetry{Identity, Rotate180, FlipRows, FlipColumns}
+}
+
+// Apply returns where position i, j of a rows by columns board moves to under the symmetry.
+func (s Symmetry) Apply(rows, columns, i, j int) (int, int) {
+	switch s {
+	case Rotate180:
+		return rows - 1 - i, columns - 1 - j
+	case FlipRows:
+		return rows - 1 - i, j
+	case FlipColumns:
+		return i, columns - 1 - j
+	case Rotate90:
+		return j, rows - 1 - i
+	case Rotate270:
+		return columns - 1 - j, i
+	case Transpose:
+		return j, i
+	case AntiTranspose:
+		return columns - 1 - j, rows - 1 - i
+	}
+	return i, j
+}
+
+// Inverse returns the symmetry that undoes s.
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	}
+	return s
+}
+
+// Transform returns a copy of board with the symmetry applied.
+// Rotate90, Rotate270, Transpose and AntiTranspose need a square board, Transform panics on other boards;
+// Symmetries only returns them for square boards.
+func Transform(board [][]int, s Symmetry) [][]int {
+	cpy := CopyBoard(board)
+	rows := len(board)
+	for i, row := range board {
+		for j, v := range row {
+			a, b := s.Apply(rows, len(row), i, j)
+			cpy[a][b] = v
+		}
+	}
+	return cpy
+}
+
+// Canonical returns the canonical form of board, the smallest of its symmetric boards in
+// row major order, and the symmetry that transforms board into it.
+func Canonical(board [][]int) ([][]int, Symmetry) {
+	best, bestSym := board, Identity
+	if len(board) == 0 {
+		return CopyBoard(board), Identity
+	}
+	for _, s := range Symmetries(len(board), len(board[0]))[1:] {
+		if b := Transform(board, s); less(b, best) {
+			best, bestSym = b, s
+		}
+	}
+	if bestSym == Identity {
+		best = CopyBoard(board)
+	}
+	return best, bestSym
+}
+
+// Key returns a string key of board: its values in row major order, one digit per position.
+// The key of the canonical form of a board identifies the position up to the board's symmetries,
+// and move i, j of a board with c columns is the row major index i*c + j of its key.
+func Key(board [][]int) string {
+	n := 0
+	for _, row := range board {
+		n += len(row)
+	}
+	b := make([]byte, 0, n)
+	for _, row := range board {
+		for _, v := range row {
+			b = append(b, byte('0'+v))
+		}
+	}
+	return string(b)
+}
+
+func less(a, b [][]int) bool {
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return a[i][j] < b[i][j]
+			}
+		}
+	}
+	return false
+}
+
+// CanonicalMove returns the canonical form of board and where position i, j moves to in it.
+// When more than one symmetry transforms board into its canonical form, the one that maps i, j to the
+// smallest row major position is used, so that moves that are equivalent by the symmetries of the position
+// itself are also the same move in the canonical form.
+func CanonicalMove(board [][]int, i, j int) ([][]int, int, int) {
+	canonical, moves := CanonicalMoves(board, [][]int{[]int{i, j}})
+	return canonical, moves[0][0], moves[0][1]
+}
+
+// CanonicalMoves returns the canonical form of board and where each of moves moves to in it,
+// in the same way as CanonicalMove but finding the canonical form only once.
+func CanonicalMoves(board [][]int, moves [][]int) ([][]int, [][]int) {
+	canonical, _ := Canonical(board)
+	if len(board) == 0 {
+		return canonical, CopyBoard(moves)
+	}
+	rows, columns := len(board), len(board[0])
+	var syms []Symmetry
+	for _, s := range Symmetries(rows, columns) {
+		if reflect.DeepEqual(Transform(board, s), canonical) {
+			syms = append(syms, s)
+		}
+	}
+	mapped := make([][]int, len(moves))
+	for n, m := range moves {
+		ci, cj := -1, -1
+		for _, s := range syms {
+			a, b := s.Apply(rows, columns, m[0], m[1])
+			if ci < 0 || a*columns+b < ci*columns+cj {
+				ci, cj = a, b
+			}
+		}
+		mapped[n] = []int{ci, cj}
+	}
+	return canonical, mapped
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSymmetry(t *testing.T) {
+	board := [][]int{
+		[]int{1, 0, 0, 0},
+		[]int{2, 0, 0, 0},
+		[]int{0, 0, 0, 1},
+		[]int{0, 0, 0, 0},
+	}
+	for _, s := range Symmetries(4, 4) {
+		got := Transform(Transform(board, s), s.Inverse())
+		if !reflect.DeepEqual(got, board) {
+			t.Errorf("Transform() with %v and its inverse = %v, want %v", s, got, board)
+		}
+		canonical, _ := Canonical(Transform(board, s))
+		want, _ := Canonical(board)
+		if !reflect.DeepEqual(canonical, want) {
+			t.Errorf("Canonical() of %v transformed board = %v, want %v", s, canonical, want)
+		}
+	}
+	if n := len(Symmetries(3, 4)); n != 4 {
+		t.Errorf("Symmetries(3, 4) has %v symmetries, want 4", n)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	board := [][]int{
+		[]int{0, 0, 1},
+		[]int{0, 2, 0},
+		[]int{0, 0, 0},
+	}
+	want := [][]int{
+		[]int{0, 0, 0},
+		[]int{0, 2, 0},
+		[]int{0, 0, 1},
+	}
+	got, s := Canonical(board)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Canonical() = %v, want %v", got, want)
+	}
+	if i, j := s.Apply(3, 3, 0, 2); got[i][j] != 1 {
+		t.Errorf("Canonical() symmetry %v maps 0, 2 to %v, %v", s, i, j)
+	}
+}
+
+func TestCanonicalMove(t *testing.T) {
+	board := emptyBoard(3, 3)
+	_, i0, j0 := CanonicalMove(board, 0, 0)
+	for _, m := range [][]int{[]int{0, 2}, []int{2, 0}, []int{2, 2}} {
+		if _, i, j := CanonicalMove(board, m[0], m[1]); i != i0 || j != j0 {
+			t.Errorf("CanonicalMove() of corner %v = %v, %v, want %v, %v", m, i, j, i0, j0)
+		}
+	}
+	if _, i, j := CanonicalMove(board, 1, 1); i != 1 || j != 1 {
+		t.Errorf("CanonicalMove() of the center = %v, %v, want 1, 1", i, j)
+	}
+}
+
+func TestCanonicalMoves(t *testing.T) {
+	board := [][]int{{1, 0, 0}, {0, 2, 0}, {0, 0, 0}}
+	moves := [][]int{{0, 1}, {1, 0}, {0, 2}, {2, 0}, {2, 2}}
+	canonical, got := CanonicalMoves(board, moves)
+	for n, m := range moves {
+		c, i, j := CanonicalMove(board, m[0], m[1])
+		if !reflect.DeepEqual(c, canonical) || got[n][0] != i || got[n][1] != j {
+			t.Errorf("CanonicalMoves() move %v = %v, want %v, %v", m, got[n], i, j)
+		}
+	}
+	// the position is symmetric along the main diagonal
+	if !reflect.DeepEqual(got[0], got[1]) || !reflect.DeepEqual(got[2], got[3]) {
+		t.Errorf("CanonicalMoves() = %v, symmetric moves differ", got)
+	}
+	if canonical, got := CanonicalMoves(nil, [][]int{{0, 0}}); len(canonical) != 0 || !reflect.DeepEqual(got, [][]int{{0, 0}}) {
+		t.Errorf("CanonicalMoves() of an empty board = %v, %v, want [], [[0 0]]", canonical, got)
+	}
+}
+
+func TestKey(t *testing.T) {
+	board := [][]int{{1, 0, 2}, {0, Blocked, 0}}
+	if got := Key(board); got != "102030" {
+		t.Errorf("Key() = %q, want %q", got, "102030")
+	}
+}
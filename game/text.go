@@ -0,0 +1,305 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ANSI escape sequences used by colored text.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[31m"
+	ansiBlue    = "\x1b[34m"
+	ansiGray    = "\x1b[90m"
+)
+
+// Format describes the text representation of a board, one line per row.
+type Format struct {
+	// Symbols are the representations of an empty position, X, O and a Blocked position.
+	Symbols [4]string
+	// Separator is written between the positions of a row.
+	Separator string
+	// RowSeparator, if not empty, is written between rows instead of a new line, which puts the
+	// whole board on a single line such as "XO-/-X-/--O".
+	RowSeparator string
+	// Coordinates adds a header line with column letters a, b, c, ... and starts every row
+	// with its number, 1 for the top row.
+	Coordinates bool
+	// Highlight is written before and after the highlighted position when Color is off.
+	Highlight [2]string
+	// Color colors X's, O's and Blocked positions with ANSI escape sequences and shows the
+	// highlighted position in reverse video.
+	Color bool
+}
+
+// DefaultFormat returns the format of Pretty: "-", "X", "O" and "#" separated by spaces.
+func DefaultFormat() Format {
+	return Format{
+		Symbols:   [4]string{"-", "X", "O", "#"},
+		Separator: " ",
+		Highlight: [2]string{"(", ")"},
+	}
+}
+
+// validate returns whether the format's symbols can be told apart when parsing.
+func (f Format) validate() error {
+	for v, s := range f.Symbols {
+		if s == "" || strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+			return ErrInvalidFormat
+		}
+		for _, skipped := range f.skipped() {
+			if s == skipped {
+				return ErrInvalidFormat
+			}
+		}
+		for _, other := range f.Symbols[:v] {
+			if s == other {
+				return ErrInvalidFormat
+			}
+		}
+		if f.RowSeparator != "" && strings.Contains(s, f.RowSeparator) {
+			return ErrInvalidFormat
+		}
+	}
+	return nil
+}
+
+// Render returns the text representation of board. The position last, typically the last move,
+// is highlighted unless it is nil.
+func (f Format) Render(board [][]int, last []int) string {
+	width := 0
+	for _, sym := range f.Symbols {
+		if len(sym) > width {
+			width = len(sym)
+		}
+	}
+	columns := 0
+	if len(board) > 0 {
+		columns = len(board[0])
+	}
+	if f.Coordinates {
+		for j := 0; j < columns; j++ {
+			if l := len(ColumnLabel(j)); l > width {
+				width = l
+			}
+		}
+	}
+	// without colors every position leaves room for the highlight marks so columns stay aligned
+	var before, after string
+	if last != nil && !f.Color {
+		before, after = f.Highlight[0], f.Highlight[1]
+	}
+	cell := func(s, open, close string) string {
+		return open + s + close + strings.Repeat(" ", width-len(s))
+	}
+	blank := func(s string) string {
+		return strings.Repeat(" ", len(s))
+	}
+	labelWidth := len(strconv.Itoa(len(board)))
+
+	var lines []string
+	if f.Coordinates {
+		line := strings.Repeat(" ", labelWidth+1)
+		for j := 0; j < columns; j++ {
+			if j > 0 {
+				line += blank(f.Separator)
+			}
+			line += cell(ColumnLabel(j), blank(before), blank(after))
+		}
+		lines = append(lines, line)
+	}
+	for i, row := range board {
+		line := ""
+		if f.Coordinates {
+			label := strconv.Itoa(i + 1)
+			line = strings.Repeat(" ", labelWidth-len(label)) + label + " "
+		}
+		for j, v := range row {
+			if j > 0 {
+				line += f.Separator
+			}
+			s := symbol(v)
+			if v >= 0 && v < len(f.Symbols) {
+				s = f.Symbols[v]
+			}
+			highlighted := len(last) == 2 && last[0] == i && last[1] == j
+			switch {
+			case f.Color:
+				line += cell(s, f.color(v, highlighted), ansiReset)
+			case highlighted:
+				line += cell(s, before, after)
+			default:
+				line += cell(s, blank(before), blank(after))
+			}
+		}
+		lines = append(lines, line)
+	}
+	for k := range lines {
+		lines[k] = strings.TrimRight(lines[k], " ")
+	}
+	if f.RowSeparator != "" {
+		return strings.Join(lines, f.RowSeparator)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// color returns the escape sequence that starts a position of value v.
+func (f Format) color(v int, highlighted bool) string {
+	c := ""
+	switch v {
+	case 1:
+		c = ansiRed
+	case 2:
+		c = ansiBlue
+	case Blocked:
+		c = ansiGray
+	}
+	if highlighted {
+		c += ansiReverse
+	}
+	return c
+}
+
+// Parse reads a board in format f, for example the output of Render, with ParseBoard and validates it
+// for the engine: its size, its symbols and the number of X's and O's. It returns the board, the side
+// to move, whether the game is over and the winner, 0 for a draw. Finished positions are accepted as
+// long as a single side won with the last move.
+func (e *Engine) Parse(s string, f Format) (board [][]int, side int, gameOver bool, winner int, err error) {
+	board, err = f.ParseBoard(s)
+	if err != nil {
+		return nil, 0, false, 0, err
+	}
+	side, gameOver, winner, err = e.outcome(board)
+	if err != nil {
+		return nil, 0, false, 0, err
+	}
+	return board, side, gameOver, winner, nil
+}
+
+// ParseBoard reads a board in format f without an engine. It only checks that the symbols are known
+// and that the board has at least one row and that all rows have the same number of positions.
+// Blank lines, spaces, separators, highlight marks and ANSI escape sequences are ignored, and so are the
+// header line and the row numbers when f has Coordinates. Rows end at new lines, and at RowSeparator
+// when it is not empty.
+func (f Format) ParseBoard(s string) ([][]int, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	s = stripANSI(s)
+	if f.RowSeparator != "" {
+		s = strings.ReplaceAll(s, f.RowSeparator, "\n")
+	}
+	var board [][]int
+	header := f.Coordinates
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if header {
+			header = false
+			continue
+		}
+		if f.Coordinates {
+			line = strings.TrimLeftFunc(line, unicode.IsSpace)
+			label := strings.IndexFunc(line, unicode.IsSpace)
+			if label < 0 {
+				return nil, ErrInvalidBoard
+			}
+			if _, err := strconv.Atoi(line[:label]); err != nil {
+				return nil, ErrInvalidBoard
+			}
+			line = line[label:]
+		}
+		row, err := f.parseRow(line)
+		if err != nil {
+			return nil, err
+		}
+		if len(board) > 0 && len(row) != len(board[0]) {
+			return nil, ErrInvalidBoard
+		}
+		board = append(board, row)
+	}
+	if len(board) == 0 {
+		return nil, ErrInvalidBoard
+	}
+	return board, nil
+}
+
+// parseRow reads the positions of a single row, matching the longest symbol at every step.
+func (f Format) parseRow(line string) ([]int, error) {
+	skip := f.skipped()
+	var row []int
+	for {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if line == "" {
+			return row, nil
+		}
+		v, n := -1, 0
+		for k, s := range f.Symbols {
+			if len(s) > n && strings.HasPrefix(line, s) {
+				v, n = k, len(s)
+			}
+		}
+		if v < 0 {
+			for _, s := range skip {
+				if strings.HasPrefix(line, s) {
+					n = len(s)
+					break
+				}
+			}
+			if n == 0 {
+				return nil, ErrInvalidBoard
+			}
+		} else {
+			row = append(row, v)
+		}
+		line = line[n:]
+	}
+}
+
+// skipped returns the separator and highlight marks without spaces, which Parse skips between positions.
+func (f Format) skipped() []string {
+	var skip []string
+	for _, s := range []string{f.Separator, f.Highlight[0], f.Highlight[1]} {
+		if s = strings.TrimSpace(s); s != "" {
+			skip = append(skip, s)
+		}
+	}
+	return skip
+}
+
+// stripANSI removes ANSI escape sequences of the form ESC [ ... letter from s.
+func stripANSI(s string) string {
+	var b strings.Builder
+	for k := 0; k < len(s); k++ {
+		if s[k] == '\x1b' && k+1 < len(s) && s[k+1] == '[' {
+			k += 2
+			for k < len(s) && !(s[k] >= 'a' && s[k] <= 'z' || s[k] >= 'A' && s[k] <= 'Z') {
+				k++
+			}
+			continue
+		}
+		b.WriteByte(s[k])
+	}
+	return b.String()
+}
+
+// ColumnLabel returns the letters of column j: a to z, then aa, ab and so on.
+func ColumnLabel(j int) string {
+	label := ""
+	for j++; j > 0; j = (j - 1) / 26 {
+		label = string(rune('a'+(j-1)%26)) + label
+	}
+	return label
+}
+
+// Render returns the text representation of the board in format f with the last move highlighted.
+func (t *TicTacToe) Render(f Format) string {
+	var last []int
+	if len(t.history) > 0 {
+		last = t.history[len(t.history)-1]
+	}
+	return f.Render(t.board, last)
+}
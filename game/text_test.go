@@ -0,0 +1,249 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormat_Render(t *testing.T) {
+	board := [][]int{{1, 2, 0}, {0, 1, Blocked}, {0, 0, 2}}
+	custom := Format{Symbols: [4]string{".", "x", "o", "[]"}, Separator: " | "}
+	coordinates := DefaultFormat()
+	coordinates.Coordinates = true
+	color := DefaultFormat()
+	color.Color = true
+	compact := Format{Symbols: [4]string{"-", "X", "O", "#"}, RowSeparator: "/"}
+	tt := []struct {
+		name string
+		f    Format
+		last []int
+		want string
+	}{
+		{
+			name: "default",
+			f:    DefaultFormat(),
+			want: "X O -\n- X #\n- - O\n",
+		},
+		{
+			name: "custom symbols and separator",
+			f:    custom,
+			want: "x  | o  | .\n.  | x  | []\n.  | .  | o\n",
+		},
+		{
+			name: "row separator",
+			f:    compact,
+			want: "XO-/-X#/--O",
+		},
+		{
+			name: "coordinates",
+			f:    coordinates,
+			want: "  a b c\n1 X O -\n2 - X #\n3 - - O\n",
+		},
+		{
+			name: "highlight",
+			f:    coordinates,
+			last: []int{2, 2},
+			want: "   a   b   c\n1  X   O   -\n2  -   X   #\n3  -   -  (O)\n",
+		},
+		{
+			name: "color",
+			f:    color,
+			last: []int{0, 0},
+			want: "\x1b[31m\x1b[7mX\x1b[0m \x1b[34mO\x1b[0m -\x1b[0m\n" +
+				"-\x1b[0m \x1b[31mX\x1b[0m \x1b[90m#\x1b[0m\n" +
+				"-\x1b[0m -\x1b[0m \x1b[34mO\x1b[0m\n",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.f.Render(board, tc.last)
+			if got != tc.want {
+				t.Errorf("Format.Render() = %q, want %q", got, tc.want)
+			}
+			e, _ := NewEngine(3, 3, 3)
+			parsed, side, gameOver, _, err := e.Parse(got, tc.f)
+			if err != nil {
+				t.Fatalf("Engine.Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(parsed, board) || side != 1 || gameOver {
+				t.Errorf("Engine.Parse() = %v, %v, %v, want %v, 1, false", parsed, side, gameOver, board)
+			}
+		})
+	}
+}
+
+func TestEngine_Parse(t *testing.T) {
+	e, _ := NewEngine(3, 4, 3)
+	tt := []struct {
+		name     string
+		s        string
+		f        Format
+		want     [][]int
+		wantSide int
+		gameOver bool
+		winner   int
+		wantErr  error
+	}{
+		{
+			name:     "blank lines and extra spaces",
+			s:        "\n  X  O - -\n\n- - - -\n- - - -  \n",
+			f:        DefaultFormat(),
+			want:     [][]int{{1, 2, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+			wantSide: 1,
+		},
+		{
+			name:     "no separator",
+			s:        "X---\n----\n----",
+			f:        Format{Symbols: [4]string{"-", "X", "O", "#"}},
+			want:     [][]int{{1, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+			wantSide: 2,
+		},
+		{
+			name:     "won by O",
+			s:        "X X - X\nO O O -\n- - - -",
+			f:        DefaultFormat(),
+			want:     [][]int{{1, 1, 0, 1}, {2, 2, 2, 0}, {0, 0, 0, 0}},
+			wantSide: 1,
+			gameOver: true,
+			winner:   2,
+		},
+		{
+			name:     "drawn",
+			s:        "X X O O\nO O X X\nX X O O",
+			f:        DefaultFormat(),
+			want:     [][]int{{1, 1, 2, 2}, {2, 2, 1, 1}, {1, 1, 2, 2}},
+			wantSide: 1,
+			gameOver: true,
+		},
+		{
+			name:    "won by O after X moved",
+			s:       "X X - X\nO O O -\n- - X -",
+			f:       DefaultFormat(),
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "unknown symbol",
+			s:       "X O - -\n- Z - -\n- - - -",
+			f:       DefaultFormat(),
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "wrong size",
+			s:       "X O -\n- - -\n- - -",
+			f:       DefaultFormat(),
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "too many X's",
+			s:       "X X - -\n- - - -\n- - - -",
+			f:       DefaultFormat(),
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "missing row number",
+			s:       "  a b c d\nX - - -\n2 - - - -\n3 - - - -",
+			f:       Format{Symbols: [4]string{"-", "X", "O", "#"}, Separator: " ", Coordinates: true},
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "repeated symbols",
+			s:       "X O - -\n- - - -\n- - - -",
+			f:       Format{Symbols: [4]string{"-", "X", "X", "#"}},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "symbol is the separator",
+			s:       "X|O|-|-\n-|-|-|-\n-|-|-|-",
+			f:       Format{Symbols: [4]string{"-", "X", "O", "|"}, Separator: "|"},
+			wantErr: ErrInvalidFormat,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, side, gameOver, winner, err := e.Parse(tc.s, tc.f)
+			if err != tc.wantErr {
+				t.Fatalf("Engine.Parse() error = %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) || side != tc.wantSide || gameOver != tc.gameOver || winner != tc.winner {
+				t.Errorf("Engine.Parse() = %v, %v, %v, %v, want %v, %v, %v, %v",
+					got, side, gameOver, winner, tc.want, tc.wantSide, tc.gameOver, tc.winner)
+			}
+		})
+	}
+}
+
+func TestFormat_ParseBoard(t *testing.T) {
+	compact := Format{Symbols: [4]string{"-", "X", "O", "#"}, RowSeparator: "/"}
+	tt := []struct {
+		name    string
+		s       string
+		f       Format
+		want    [][]int
+		wantErr error
+	}{
+		{
+			name: "row separator and new lines",
+			s:    "XX-/--O\nOO#\n",
+			f:    compact,
+			want: [][]int{{1, 1, 0}, {0, 0, 2}, {2, 2, Blocked}},
+		},
+		{
+			name: "any size",
+			s:    "X O - - -",
+			f:    DefaultFormat(),
+			want: [][]int{{1, 2, 0, 0, 0}},
+		},
+		{
+			name:    "ragged",
+			s:       "XO-/-X",
+			f:       compact,
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "empty",
+			s:       "/\n",
+			f:       compact,
+			wantErr: ErrInvalidBoard,
+		},
+		{
+			name:    "symbol contains the row separator",
+			s:       "X/O",
+			f:       Format{Symbols: [4]string{"-", "X", "O", "/"}, RowSeparator: "/"},
+			wantErr: ErrInvalidFormat,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f.ParseBoard(tc.s)
+			if err != tc.wantErr {
+				t.Fatalf("Format.ParseBoard() error = %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Format.ParseBoard() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicTacToe_Render(t *testing.T) {
+	e, _ := NewEngine(3, 3, 3)
+	g, _ := New(e, NewTestPlayer([][]int{{1, 1}}, "X"), NewTestPlayer([][]int{{0, 2}}, "O"))
+	g.Play()
+	g.Play()
+	want := " -   -  (O)\n -   X   -\n -   -   -\n"
+	if got := g.Render(DefaultFormat()); got != want {
+		t.Errorf("TicTacToe.Render() = %q, want %q", got, want)
+	}
+
+	// a finished game reads back as a finished position
+	g, _ = New(e, NewTestPlayer([][]int{{1, 1}, {0, 0}, {2, 2}}, "X"), NewTestPlayer([][]int{{0, 2}, {2, 0}}, "O"))
+	for g.Play() {
+	}
+	board, _, gameOver, winner, err := e.Parse(g.Render(DefaultFormat()), DefaultFormat())
+	if err != nil || !reflect.DeepEqual(board, g.Board()) || !gameOver || winner != 1 {
+		t.Errorf("Engine.Parse() of a finished game = %v, %v, %v, %v, want %v, true, 1, nil", board, gameOver, winner, err, g.Board())
+	}
+	if got := ColumnLabel(27); got != "ab" {
+		t.Errorf("ColumnLabel(27) = %q, want \"ab\"", got)
+	}
+}
@@ -0,0 +1,195 @@
+package game
+
+// ThreatKind is the kind of a threat, named after Gomoku's terms for a target of 5.
+type ThreatKind int
+
+const (
+	// ThreatThree is a move that leaves target-2 symbols in an open window that turns into
+	// a ThreatStraightFour with one more move unless it is defended.
+	ThreatThree ThreatKind = iota
+	// ThreatFour is a move that leaves target-1 symbols in a line with a single position left to win.
+	ThreatFour
+	// ThreatStraightFour is a move that leaves two or more positions to win, it can not be defended.
+	ThreatStraightFour
+)
+
+// Threat is a move that forces the opponent to answer.
+type Threat struct {
+	Kind ThreatKind
+	// Gain is the position of the threatening move.
+	Gain []int
+	// Cost holds the positions the opponent can answer the threat with.
+	// For fours, these are the positions that complete the line.
+	Cost [][]int
+}
+
+// Threats returns every threat side can make with a single move on board.
+// It returns nil if board does not match the engine's size or side is invalid.
+func (e *Engine) Threats(board [][]int, side int) []Threat {
+	if !e.fits(board) || (side != 1 && side != 2) {
+		return nil
+	}
+	return e.threats(CopyBoard(board), side)
+}
+
+// threats is Threats for a board of the engine's size that it may modify while it searches.
+func (e *Engine) threats(board [][]int, side int) []Threat {
+	var threats []Threat
+	for i := 0; i < e.rows; i++ {
+		for j := 0; j < e.columns; j++ {
+			if board[i][j] != 0 {
+				continue
+			}
+			board[i][j] = side
+			if t, ok := e.threat(board, side, i, j); ok {
+				threats = append(threats, t)
+			}
+			board[i][j] = 0
+		}
+	}
+	return threats
+}
+
+// threat classifies the threat made by side's symbol at i, j, which is already on the board.
+// Only the strongest threat of a move is reported.
+func (e *Engine) threat(board [][]int, side, i, j int) (Threat, bool) {
+	var wins [][]int
+	var three [][]int
+	for _, d := range directions2D {
+		// windows of target positions that contain i, j
+		for s := -(e.target - 1); s <= 0; s++ {
+			cells, own, ok := e.window(board, side, i+s*d[0], j+s*d[1], d, e.target)
+			if ok && own == e.target-1 {
+				for _, c := range cells {
+					if board[c[0]][c[1]] == 0 {
+						wins = appendUnique(wins, c)
+					}
+				}
+			}
+		}
+		if three != nil {
+			continue
+		}
+		// windows of target+1 positions with both ends empty that contain i, j inside
+		for s := -(e.target - 1); s <= -1; s++ {
+			cells, own, ok := e.window(board, side, i+s*d[0], j+s*d[1], d, e.target+1)
+			if !ok || own != e.target-2 {
+				continue
+			}
+			first, last := cells[0], cells[len(cells)-1]
+			if board[first[0]][first[1]] != 0 || board[last[0]][last[1]] != 0 {
+				continue
+			}
+			var cost [][]int
+			for _, c := range cells {
+				if board[c[0]][c[1]] == 0 {
+					cost = append(cost, c)
+				}
+			}
+			three = cost
+			break
+		}
+	}
+	gain := []int{i, j}
+	switch {
+	case len(wins) >= 2:
+		return Threat{Kind: ThreatStraightFour, Gain: gain, Cost: wins}, true
+	case len(wins) == 1:
+		return Threat{Kind: ThreatFour, Gain: gain, Cost: wins}, true
+	case three != nil:
+		return Threat{Kind: ThreatThree, Gain: gain, Cost: three}, true
+	}
+	return Threat{}, false
+}
+
+// window returns the positions of a window of length n starting at i, j in direction d,
+// the number of side's symbols in it and whether the window is on the board and free of
+// other sides' symbols and blocked positions.
+func (e *Engine) window(board [][]int, side, i, j int, d [2]int, n int) ([][]int, int, bool) {
+	cells := make([][]int, 0, n)
+	own := 0
+	for k := 0; k < n; k++ {
+		a, b := i+k*d[0], j+k*d[1]
+		if a < 0 || b < 0 || a >= e.rows || b >= e.columns {
+			return nil, 0, false
+		}
+		switch board[a][b] {
+		case 0:
+		case side:
+			own++
+		default:
+			return nil, 0, false
+		}
+		cells = append(cells, []int{a, b})
+	}
+	return cells, own, true
+}
+
+// ThreatSearch searches for a sequence of at most depth threats that wins for side, to move on board.
+// It returns the attacker's moves of the winning sequence, the last one of which either completes
+// a line or leaves a threat that can not be defended.
+// Like a classic threat-space search, it assumes the opponent answers every threat by occupying
+// all of its cost positions at once and only considers the opponent's immediate wins, which makes it
+// much faster than a full search on large boards. Sequences should be verified when certainty is required.
+func (e *Engine) ThreatSearch(board [][]int, side, depth int) ([][]int, bool) {
+	if !e.fits(board) || (side != 1 && side != 2) {
+		return nil, false
+	}
+	return e.threatSearch(CopyBoard(board), side, depth)
+}
+
+func (e *Engine) threatSearch(board [][]int, side, depth int) ([][]int, bool) {
+	if wins := e.WinningMoves(board, side); len(wins) > 0 {
+		return wins[:1], true
+	}
+	if depth == 0 || len(e.WinningMoves(board, 3-side)) > 0 {
+		return nil, false
+	}
+	for _, t := range e.threats(board, side) {
+		if t.Kind == ThreatStraightFour {
+			return [][]int{t.Gain}, true
+		}
+	}
+	for _, t := range e.threats(board, side) {
+		board[t.Gain[0]][t.Gain[1]] = side
+		for _, c := range t.Cost {
+			board[c[0]][c[1]] = 3 - side
+		}
+		rest, ok := e.threatSearch(board, side, depth-1)
+		for _, c := range t.Cost {
+			board[c[0]][c[1]] = 0
+		}
+		board[t.Gain[0]][t.Gain[1]] = 0
+		if ok {
+			return append([][]int{t.Gain}, rest...), true
+		}
+	}
+	return nil, false
+}
+
+// completes returns whether side's symbol at i, j would be part of target consecutive symbols.
+// The position i, j itself is not read, so it can be empty.
+func (e *Engine) completes(board [][]int, side, i, j int) bool {
+	for _, d := range directions2D {
+		cnt := 1
+		for a, b := i+d[0], j+d[1]; a >= 0 && b >= 0 && a < e.rows && b < e.columns && board[a][b] == side; a, b = a+d[0], b+d[1] {
+			cnt++
+		}
+		for a, b := i-d[0], j-d[1]; a >= 0 && b >= 0 && a < e.rows && b < e.columns && board[a][b] == side; a, b = a-d[0], b-d[1] {
+			cnt++
+		}
+		if cnt >= e.target {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(cells [][]int, c []int) [][]int {
+	for _, v := range cells {
+		if v[0] == c[0] && v[1] == c[1] {
+			return cells
+		}
+	}
+	return append(cells, c)
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func emptyBoard(rows, columns int) [][]int {
+	board := make([][]int, rows)
+	for i := range board {
+		board[i] = make([]int, columns)
+	}
+	return board
+}
+
+func TestEngine_Threats(t *testing.T) {
+	e, _ := NewEngine(9, 9, 5)
+	openThree := emptyBoard(9, 9)
+	openThree[4][3], openThree[4][4], openThree[4][5] = 1, 1, 1
+	closedThree := emptyBoard(9, 9)
+	closedThree[4][0], closedThree[4][1], closedThree[4][2] = 1, 1, 1
+	closedThree[4][3] = 2
+	tests := []struct {
+		name  string
+		board [][]int
+		side  int
+		gain  []int
+		want  Threat
+		found bool
+	}{
+		{
+			name:  "extending an open three makes a straight four",
+			board: openThree,
+			side:  1,
+			gain:  []int{4, 6},
+			want:  Threat{Kind: ThreatStraightFour, Gain: []int{4, 6}, Cost: [][]int{[]int{4, 2}, []int{4, 7}}},
+			found: true,
+		},
+		{
+			name:  "filling a gap next to an open three makes a four",
+			board: openThree,
+			side:  1,
+			gain:  []int{4, 7},
+			want:  Threat{Kind: ThreatFour, Gain: []int{4, 7}, Cost: [][]int{[]int{4, 6}}},
+			found: true,
+		},
+		{
+			name:  "a lone stone away from the closed three is not a threat",
+			board: closedThree,
+			side:  1,
+			gain:  []int{3, 1},
+			found: false,
+		},
+		{
+			name:  "O has no threats",
+			board: openThree,
+			side:  2,
+			gain:  []int{0, 0},
+			found: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Threat
+			found := false
+			for _, th := range e.Threats(tt.board, tt.side) {
+				if reflect.DeepEqual(th.Gain, tt.gain) {
+					got, found = th, true
+				}
+			}
+			if found != tt.found {
+				t.Fatalf("Engine.Threats() found %v = %v, want %v", tt.gain, found, tt.found)
+			}
+			if found && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Engine.Threats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_ThreatsInvalid(t *testing.T) {
+	e, _ := NewEngine(5, 5, 4)
+	if got := e.Threats(emptyBoard(3, 3), 1); got != nil {
+		t.Errorf("Engine.Threats() of a board of the wrong size = %v, want nil", got)
+	}
+	if got, ok := e.ThreatSearch(emptyBoard(3, 3), 1, 2); got != nil || ok {
+		t.Errorf("Engine.ThreatSearch() of a board of the wrong size = %v, %v, want nil, false", got, ok)
+	}
+	board := emptyBoard(5, 5)
+	board[2][1], board[2][2] = 1, 1
+	if got := e.Threats(board, 3); got != nil {
+		t.Errorf("Engine.Threats() of an invalid side = %v, want nil", got)
+	}
+	// Threats works on a copy of the board
+	cpy := CopyBoard(board)
+	if len(e.Threats(board, 1)) == 0 || !reflect.DeepEqual(board, cpy) {
+		t.Errorf("Engine.Threats() board = %v, want %v", board, cpy)
+	}
+}
+
+func TestEngine_ThreatSearch(t *testing.T) {
+	e, _ := NewEngine(9, 9, 5)
+	doubleThree := emptyBoard(9, 9)
+	doubleThree[4][4], doubleThree[4][5] = 1, 1
+	doubleThree[5][3], doubleThree[6][3] = 1, 1
+	doubleThree[0][0], doubleThree[0][8], doubleThree[8][0] = 2, 2, 2
+	blocked := emptyBoard(9, 9)
+	blocked[4][4], blocked[4][5] = 1, 1
+	blocked[4][3], blocked[4][6] = 2, 2
+	tests := []struct {
+		name   string
+		board  [][]int
+		side   int
+		depth  int
+		want   [][]int
+		wantOK bool
+	}{
+		{
+			name:   "double three wins in two threats",
+			board:  doubleThree,
+			side:   1,
+			depth:  2,
+			want:   [][]int{[]int{4, 3}, []int{3, 3}},
+			wantOK: true,
+		},
+		{
+			name:   "double three needs two threats",
+			board:  doubleThree,
+			side:   1,
+			depth:  1,
+			wantOK: false,
+		},
+		{
+			name:   "no threats left",
+			board:  blocked,
+			side:   1,
+			depth:  3,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := e.ThreatSearch(tt.board, tt.side, tt.depth)
+			if ok != tt.wantOK {
+				t.Fatalf("Engine.ThreatSearch() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Engine.ThreatSearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
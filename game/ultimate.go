@@ -0,0 +1,224 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mraufc/tictactoe/player"
+)
+
+// drawn marks a local board that is over without a winner on the meta board,
+// it blocks the meta board cell for both sides.
+const drawn = Blocked
+
+// Ultimate is the Ultimate TicTacToe variant: a grid of local boards where the position of a move
+// inside its local board decides which local board the opponent must play next.
+// Winning a local board claims the corresponding cell of the meta board, and the first side
+// to win the meta board wins the game.
+type Ultimate struct {
+	board    [][]int // 0 -> empty position, 1 -> X, 2 -> O
+	meta     [][]int // 0 -> open local board, 1 -> won by X, 2 -> won by O, 3 -> drawn
+	player1  player.UltimatePlayer
+	player2  player.UltimatePlayer
+	winner   int // 0 is a tie or draw
+	gameOver bool
+	moves    int
+	nextI    int // -1 when any open local board can be played
+	nextJ    int
+	local    *Engine
+	outer    *Engine
+}
+
+// NewUltimate returns a new game of Ultimate TicTacToe.
+// local engine evaluates each local board and outer engine evaluates the meta board.
+// Since a move's position in its local board selects the opponent's next local board,
+// both engines must have the same number of rows and columns.
+func NewUltimate(local, outer *Engine, player1, player2 player.UltimatePlayer) (*Ultimate, error) {
+	if local == nil || outer == nil || player1 == nil || player2 == nil {
+		return nil, ErrInvalidGameSpecs
+	}
+	if local.rows != outer.rows || local.columns != outer.columns {
+		return nil, ErrInvalidGameSpecs
+	}
+	board := make([][]int, local.rows*outer.rows)
+	for i := range board {
+		board[i] = make([]int, local.columns*outer.columns)
+	}
+	meta := make([][]int, outer.rows)
+	for i := range meta {
+		meta[i] = make([]int, outer.columns)
+	}
+	return &Ultimate{
+		board:   board,
+		meta:    meta,
+		player1: player1,
+		player2: player2,
+		nextI:   -1,
+		nextJ:   -1,
+		local:   local,
+		outer:   outer,
+	}, nil
+}
+
+// Next returns the local board that must be played next, or -1, -1 when any open local board can be played.
+func (u *Ultimate) Next() (int, int) {
+	return u.nextI, u.nextJ
+}
+
+// Legal returns whether a move to i, j in board coordinates is legal in the current position.
+func (u *Ultimate) Legal(i, j int) bool {
+	if u.gameOver || i < 0 || j < 0 || i >= len(u.board) || j >= len(u.board[i]) || u.board[i][j] != 0 {
+		return false
+	}
+	bi, bj := i/u.local.rows, j/u.local.columns
+	if u.meta[bi][bj] != 0 {
+		return false
+	}
+	return u.nextI < 0 || (bi == u.nextI && bj == u.nextJ)
+}
+
+// Play calls the Play function of the appropriate player and evaluates the move on its local board
+// and, when the local board is decided, on the meta board.
+// A player that makes an illegal move loses immediately.
+// This function returns true as long as game is not over.
+func (u *Ultimate) Play() bool {
+	if u.gameOver {
+		return false
+	}
+
+	board := CopyBoard(u.board)
+	meta := CopyBoard(u.meta)
+	var i, j, side int
+	if u.moves%2 == 0 {
+		side = 1
+		i, j = u.player1.Play(board, meta, u.nextI, u.nextJ, 1)
+	} else {
+		side = 2
+		i, j = u.player2.Play(board, meta, u.nextI, u.nextJ, 2)
+	}
+	if !u.Legal(i, j) {
+		u.finish(3 - side)
+		return false
+	}
+
+	bi, bj := i/u.local.rows, j/u.local.columns
+	li, lj := i%u.local.rows, j%u.local.columns
+	sub := make([][]int, u.local.rows)
+	unoccupied := 0
+	for r := range sub {
+		sub[r] = u.board[bi*u.local.rows+r][bj*u.local.columns : (bj+1)*u.local.columns]
+		for _, v := range sub[r] {
+			if v == 0 {
+				unoccupied++
+			}
+		}
+	}
+	localOver, localWinner := u.local.evaluate(sub, side, li, lj, unoccupied)
+	u.board[i][j] = side
+	u.moves++
+
+	if localOver {
+		open := 0
+		for _, row := range u.meta {
+			for _, v := range row {
+				if v == 0 {
+					open++
+				}
+			}
+		}
+		if localWinner == side {
+			u.gameOver, u.winner = u.outer.evaluate(u.meta, side, bi, bj, open)
+			u.meta[bi][bj] = side
+		} else {
+			u.meta[bi][bj] = drawn
+			u.gameOver = open == 1
+		}
+		if u.gameOver {
+			u.finish(u.winner)
+			return false
+		}
+	}
+
+	u.nextI, u.nextJ = li, lj
+	if u.meta[li][lj] != 0 {
+		u.nextI, u.nextJ = -1, -1
+	}
+	return true
+}
+
+func (u *Ultimate) finish(winner int) {
+	u.gameOver = true
+	u.winner = winner
+	u.player1.Done(winner)
+	u.player2.Done(winner)
+}
+
+// Result returns if the game is still in progress and the winner
+func (u *Ultimate) Result() (bool, int) {
+	return !u.gameOver, u.winner
+}
+
+// Pretty returns a pretty string representation of the board with local boards separated by lines,
+// followed by the meta board where '#' is a drawn local board.
+func (u *Ultimate) Pretty() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v as 'X' vs. %v as 'O'\n", u.player1.Name(), u.player2.Name())
+	separator := strings.Repeat("-", 2*u.local.columns-1)
+	for i, row := range u.board {
+		if i > 0 && i%u.local.rows == 0 {
+			for bj := 0; bj < u.outer.columns; bj++ {
+				if bj > 0 {
+					sb.WriteString("-+-")
+				}
+				sb.WriteString(separator)
+			}
+			sb.WriteString("\n")
+		}
+		for j, v := range row {
+			if j > 0 {
+				if j%u.local.columns == 0 {
+					sb.WriteString(" | ")
+				} else {
+					sb.WriteString(" ")
+				}
+			}
+			sb.WriteString(symbol(v))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Meta board\n")
+	for _, row := range u.meta {
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(symbol(v))
+		}
+		sb.WriteString("\n")
+	}
+	if !u.gameOver {
+		sb.WriteString("Game is still in progress")
+	} else {
+		switch u.winner {
+		case 1:
+			fmt.Fprintf(&sb, "Winner is %v as 'X'", u.player1.Name())
+		case 2:
+			fmt.Fprintf(&sb, "Winner is %v as 'O'", u.player2.Name())
+		case 0:
+			sb.WriteString("Game is a Draw!")
+		}
+	}
+	return sb.String()
+}
+
+func symbol(v int) string {
+	switch v {
+	case 1:
+		return "X"
+	case 2:
+		return "O"
+	case Blocked:
+		return "#"
+	}
+	return "-"
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewUltimate(t *testing.T) {
+	e3, _ := NewEngine(3, 3, 3)
+	e4, _ := NewEngine(4, 4, 3)
+	p1 := &testUltimatePlayer{name: "p1"}
+	p2 := &testUltimatePlayer{name: "p2"}
+	if _, err := NewUltimate(e3, e3, p1, p2); err != nil {
+		t.Errorf("NewUltimate() error = %v, want nil", err)
+	}
+	if _, err := NewUltimate(e3, e4, p1, p2); err == nil {
+		t.Errorf("NewUltimate() with mismatched engines error = nil, want error")
+	}
+	if _, err := NewUltimate(e3, e3, p1, nil); err == nil {
+		t.Errorf("NewUltimate() with nil player error = nil, want error")
+	}
+}
+
+func TestUltimate_Play(t *testing.T) {
+	type want struct {
+		result   bool
+		winner   int
+		nextI    int
+		nextJ    int
+		metaCell int
+	}
+	tests := []struct {
+		name   string
+		board  [][]int
+		meta   [][]int
+		nextI  int
+		nextJ  int
+		moves  int
+		player []int
+		want   want
+	}{
+		{
+			name: "first move sends the opponent to the matching local board",
+			board: [][]int{
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			meta:   [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			nextI:  -1,
+			nextJ:  -1,
+			player: []int{4, 2},
+			want:   want{result: true, winner: 0, nextI: 1, nextJ: 2},
+		},
+		{
+			name: "playing outside of the forced local board loses",
+			board: [][]int{
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			meta:   [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			nextI:  1,
+			nextJ:  2,
+			player: []int{0, 0},
+			want:   want{result: false, winner: 2, nextI: 1, nextJ: 2},
+		},
+		{
+			name: "winning a local board claims the meta cell",
+			board: [][]int{
+				{1, 1, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 2, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			meta:   [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			nextI:  0,
+			nextJ:  0,
+			moves:  4,
+			player: []int{0, 2},
+			want:   want{result: true, winner: 0, nextI: 0, nextJ: 2, metaCell: 1},
+		},
+		{
+			name: "winning the meta board wins the game",
+			board: [][]int{
+				{1, 1, 1, 1, 1, 1, 1, 1, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{2, 2, 0, 2, 2, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			meta:   [][]int{{1, 1, 0}, {0, 0, 0}, {0, 0, 0}},
+			nextI:  0,
+			nextJ:  2,
+			moves:  10,
+			player: []int{0, 8},
+			want:   want{result: false, winner: 1, nextI: 0, nextJ: 2, metaCell: 1},
+		},
+	}
+	local, _ := NewEngine(3, 3, 3)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &testUltimatePlayer{move: tt.player, name: "p"}
+			u := &Ultimate{
+				board:   tt.board,
+				meta:    tt.meta,
+				player1: p,
+				player2: p,
+				moves:   tt.moves,
+				nextI:   tt.nextI,
+				nextJ:   tt.nextJ,
+				local:   local,
+				outer:   local,
+			}
+			if got := u.Play(); got != tt.want.result {
+				t.Errorf("Ultimate.Play() = %v, want %v", got, tt.want.result)
+			}
+			if u.winner != tt.want.winner {
+				t.Errorf("Ultimate.Play() winner = %v, want %v", u.winner, tt.want.winner)
+			}
+			if i, j := u.Next(); i != tt.want.nextI || j != tt.want.nextJ {
+				t.Errorf("Ultimate.Next() = %v, %v, want %v, %v", i, j, tt.want.nextI, tt.want.nextJ)
+			}
+			bi, bj := tt.player[0]/3, tt.player[1]/3
+			if u.meta[bi][bj] != tt.want.metaCell {
+				t.Errorf("Ultimate.Play() meta cell = %v, want %v", u.meta[bi][bj], tt.want.metaCell)
+			}
+		})
+	}
+}
+
+// testUltimatePlayer implements UltimatePlayer interface and always plays the same move
+type testUltimatePlayer struct {
+	move   []int
+	name   string
+	winner int
+}
+
+func (tp *testUltimatePlayer) Name() string {
+	return tp.name
+}
+
+func (tp *testUltimatePlayer) Play(board [][]int, meta [][]int, bi, bj, side int) (int, int) {
+	return tp.move[0], tp.move[1]
+}
+
+func (tp *testUltimatePlayer) Done(winner int) {
+	tp.winner = winner
+}
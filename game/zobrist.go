@@ -0,0 +1,67 @@
+package game
+
+// Zobrist holds the random keys of Zobrist hashing for an engine's board.
+// A position's hash is the XOR of the keys of its occupied positions, and of the side key when O is to move,
+// so it can be updated incrementally with a single XOR per move.
+// Keys are generated from a seed derived from the engine's rows, columns and target, which makes
+// hashes of the same configuration identical across runs and machines.
+type Zobrist struct {
+	rows    int
+	columns int
+	keys    []uint64 // 3 keys per position, for X, O and Blocked
+	side    uint64
+}
+
+// NewZobrist returns the Zobrist keys for the engine's board.
+func NewZobrist(e *Engine) *Zobrist {
+	seed := uint64(e.rows)<<40 | uint64(e.columns)<<20 | uint64(e.target)
+	z := &Zobrist{
+		rows:    e.rows,
+		columns: e.columns,
+		keys:    make([]uint64, 3*e.rows*e.columns),
+	}
+	for k := range z.keys {
+		z.keys[k] = splitmix64(&seed)
+	}
+	z.side = splitmix64(&seed)
+	return z
+}
+
+// Hash returns the hash of board with side to move.
+// Values other than 1, 2 and Blocked are treated as unoccupied positions.
+func (z *Zobrist) Hash(board [][]int, side int) uint64 {
+	var h uint64
+	for i := 0; i < z.rows && i < len(board); i++ {
+		for j := 0; j < z.columns && j < len(board[i]); j++ {
+			h = z.Toggle(h, i, j, board[i][j])
+		}
+	}
+	if side == 2 {
+		h ^= z.side
+	}
+	return h
+}
+
+// Toggle adds or removes value v at i, j to or from hash h and returns the new hash.
+// h is returned unchanged for positions outside of the board and values other than 1, 2 and Blocked.
+func (z *Zobrist) Toggle(h uint64, i, j, v int) uint64 {
+	if v < 1 || v > Blocked || i < 0 || j < 0 || i >= z.rows || j >= z.columns {
+		return h
+	}
+	return h ^ z.keys[3*(i*z.columns+j)+v-1]
+}
+
+// ToggleSide switches the side to move of hash h and returns the new hash.
+func (z *Zobrist) ToggleSide(h uint64) uint64 {
+	return h ^ z.side
+}
+
+// splitmix64 returns the next value of the SplitMix64 generator, which is fully specified
+// so that keys do not depend on the standard library's random number generators.
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
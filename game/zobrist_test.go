@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestZobrist(t *testing.T) {
+	e, _ := NewEngine(4, 4, 3)
+	other, _ := NewEngine(4, 4, 4)
+	z := NewZobrist(e)
+
+	if h := z.Hash(emptyBoard(4, 4), 1); h != 0 {
+		t.Errorf("Zobrist.Hash() of the empty board = %v, want 0", h)
+	}
+	if h := z.Toggle(z.Toggle(0, 1, 2, 1), 1, 2, 1); h != 0 {
+		t.Errorf("Zobrist.Toggle() twice = %v, want 0", h)
+	}
+	if z.Toggle(0, 1, 2, 1) == z.Toggle(0, 1, 2, 2) {
+		t.Errorf("Zobrist.Toggle() of X and O are equal")
+	}
+	for _, m := range [][3]int{{-1, 0, 1}, {0, -1, 1}, {4, 0, 1}, {0, 4, 1}, {0, 0, 4}} {
+		if h := z.Toggle(7, m[0], m[1], m[2]); h != 7 {
+			t.Errorf("Zobrist.Toggle(%v) = %v, want 7", m, h)
+		}
+	}
+	board := [][]int{
+		[]int{1, 0, 0, 0},
+		[]int{0, 2, 0, 0},
+		[]int{0, 0, 3, 0},
+		[]int{0, 0, 0, 0},
+	}
+	if z.Hash(board, 2) != NewZobrist(e).Hash(board, 2) {
+		t.Errorf("Zobrist.Hash() is not deterministic for the same engine configuration")
+	}
+	if z.Hash(board, 2) == NewZobrist(other).Hash(board, 2) {
+		t.Errorf("Zobrist.Hash() is equal for different engine configurations")
+	}
+	if z.Hash(board, 1) == z.Hash(board, 2) {
+		t.Errorf("Zobrist.Hash() does not depend on the side to move")
+	}
+}
+
+func TestState_Hash(t *testing.T) {
+	e, _ := NewEngine(4, 4, 3)
+	a, _ := NewState(e, nil)
+	b, _ := NewState(e, nil)
+	empty := a.Hash()
+	// transpositions reach the same hash
+	for _, m := range [][]int{[]int{0, 0}, []int{1, 1}, []int{2, 3}, []int{3, 3}} {
+		a.Do(m[0], m[1])
+	}
+	for _, m := range [][]int{[]int{2, 3}, []int{3, 3}, []int{0, 0}, []int{1, 1}} {
+		b.Do(m[0], m[1])
+	}
+	if a.Hash() != b.Hash() {
+		t.Errorf("State.Hash() of transpositions = %v and %v, want equal", a.Hash(), b.Hash())
+	}
+	if a.Hash() != a.Zobrist().Hash(a.board, a.Side()) {
+		t.Errorf("State.Hash() = %v, want %v", a.Hash(), a.Zobrist().Hash(a.board, a.Side()))
+	}
+	for a.Undo() == nil {
+	}
+	if a.Hash() != empty {
+		t.Errorf("State.Hash() after undoing every move = %v, want %v", a.Hash(), empty)
+	}
+}
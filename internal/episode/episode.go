@@ -0,0 +1,44 @@
+// Package episode records the moves learning players make during a game so that they can learn
+// from them when the game is over.
+package episode
+
+// Step is a move made in a position: the key of the position's canonical board and the row major
+// index of the move in it.
+type Step struct {
+	Key  string
+	Move int
+}
+
+// Episode holds the moves of the current game by side. TicTacToe calls Done of both players,
+// so a player that plays both sides of a game is told the result twice; End reports the moves
+// of a game only once.
+type Episode struct {
+	steps [3][]Step
+	moves [3]int
+	moved bool
+}
+
+// Moves returns the number of moves side has made in the current game.
+func (e *Episode) Moves(side int) int {
+	return e.moves[side]
+}
+
+// Play counts a move of side, and records its step if record is true.
+func (e *Episode) Play(side int, s Step, record bool) {
+	e.moved = true
+	e.moves[side]++
+	if record {
+		e.steps[side] = append(e.steps[side], s)
+	}
+}
+
+// End ends the current game and returns the recorded steps by side. It returns false if no move
+// was made since the last call, such as the second Done call of a player that plays both sides.
+func (e *Episode) End() ([3][]Step, bool) {
+	if !e.moved {
+		return [3][]Step{}, false
+	}
+	steps := e.steps
+	*e = Episode{}
+	return steps, true
+}
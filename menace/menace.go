@@ -0,0 +1,92 @@
+// Package menace implements a matchbox learning player in the spirit of Donald Michie's MENACE
+// (Machine Educable Noughts And Crosses Engine).
+//
+// Every position the player meets has a matchbox with beads for each of its moves. The player
+// draws a bead at random to choose a move, so moves with more beads are played more often. When
+// the game is over the beads of the moves it played are reinforced: beads are added for a win or a
+// draw and taken away for a loss. Positions are stored in their canonical form under the board's
+// symmetries, like the original machine that had a single matchbox for symmetric positions.
+package menace
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// ErrInvalidMatchboxes is returned when matchboxes can not be read or do not match an engine.
+var ErrInvalidMatchboxes = errors.New("invalid matchboxes")
+
+// Matchboxes are the bead counts of the positions of an engine's board.
+type Matchboxes struct {
+	e     *game.Engine
+	boxes map[string]map[int]int // canonical board -> move index in the canonical board -> beads
+}
+
+// New returns new empty matchboxes for the engine's board.
+func New(e *game.Engine) (*Matchboxes, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Matchboxes{e: e, boxes: make(map[string]map[int]int)}, nil
+}
+
+// Len returns the number of matchboxes, one per position met.
+func (m *Matchboxes) Len() int {
+	return len(m.boxes)
+}
+
+// Beads returns the number of beads of move i, j in the matchbox of board and whether there is
+// a matchbox for board.
+func (m *Matchboxes) Beads(board [][]int, i, j int) (int, bool) {
+	canonical, ci, cj := game.CanonicalMove(board, i, j)
+	box, ok := m.boxes[game.Key(canonical)]
+	return box[ci*m.e.Columns()+cj], ok
+}
+
+type jsonMatchboxes struct {
+	Rows    int                    `json:"rows"`
+	Columns int                    `json:"columns"`
+	Target  int                    `json:"target"`
+	Boxes   map[string]map[int]int `json:"boxes"`
+}
+
+// Write writes the matchboxes to w in JSON format.
+// Positions are keyed by the game.Key of their canonical board and moves by their row major index.
+func (m *Matchboxes) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(jsonMatchboxes{
+		Rows:    m.e.Rows(),
+		Columns: m.e.Columns(),
+		Target:  m.e.Target(),
+		Boxes:   m.boxes,
+	})
+}
+
+// Read reads matchboxes written by Write for the engine's board.
+func Read(r io.Reader, e *game.Engine) (*Matchboxes, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	var jm jsonMatchboxes
+	if err := json.NewDecoder(r).Decode(&jm); err != nil {
+		return nil, err
+	}
+	if jm.Rows != e.Rows() || jm.Columns != e.Columns() || jm.Target != e.Target() {
+		return nil, ErrInvalidMatchboxes
+	}
+	m, _ := New(e)
+	for k, box := range jm.Boxes {
+		if len(k) != e.Rows()*e.Columns() {
+			return nil, ErrInvalidMatchboxes
+		}
+		for move, beads := range box {
+			if move < 0 || move >= len(k) || k[move] != '0' || beads < 0 {
+				return nil, ErrInvalidMatchboxes
+			}
+		}
+		m.boxes[k] = box
+	}
+	return m, nil
+}
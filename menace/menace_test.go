@@ -0,0 +1,148 @@
+package menace
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// randomPlayer plays uniformly random moves by always drawing from fresh matchboxes.
+func randomPlayer(e *game.Engine, seed int64) *Player {
+	m, _ := New(e)
+	p, _ := NewPlayer(m, "random", Config{Initial: []int{1}, Seed: seed})
+	return p
+}
+
+func TestPlayer_Play(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	m, _ := New(e)
+	p, err := NewPlayer(m, "menace", DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	p.Play(board, 1)
+	if m.Len() != 1 {
+		t.Fatalf("Matchboxes.Len() = %v, want 1", m.Len())
+	}
+	// the empty board has three distinct moves: a corner, an edge and the center
+	if box := m.boxes[game.Key(board)]; len(box) != 3 {
+		t.Errorf("matchbox of the empty board = %v, want 3 moves", box)
+	}
+	if beads, ok := m.Beads(board, 2, 2); !ok || beads != 4 {
+		t.Errorf("Matchboxes.Beads() = %v, %v, want 4, true", beads, ok)
+	}
+
+	for k := range m.boxes[game.Key(board)] {
+		m.boxes[game.Key(board)][k] = 0
+	}
+	if i, j := p.Play(board, 1); i != -1 || j != -1 {
+		t.Errorf("Player.Play() from an empty matchbox = (%v, %v), want (-1, -1)", i, j)
+	}
+	// a resignation on the first move is a game too
+	resigning, _ := NewPlayer(m, "menace", DefaultConfig())
+	resigning.Play(board, 1)
+	resigning.Done(2)
+	if resigning.Games() != 1 {
+		t.Errorf("Player.Games() after a resignation = %v, want 1", resigning.Games())
+	}
+	if _, err := NewPlayer(m, "menace", Config{Initial: []int{0}}); err != game.ErrInvalidGameSpecs {
+		t.Errorf("NewPlayer() error = %v, want %v", err, game.ErrInvalidGameSpecs)
+	}
+}
+
+func TestPlayer_Initial(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	tests := []struct {
+		name  string
+		learn bool
+		boxes int
+		beads int
+	}{
+		{"learn", true, 2, 3},
+		{"no learn", false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := New(e)
+			cfg := DefaultConfig()
+			cfg.Learn = tt.learn
+			p, _ := NewPlayer(m, "menace", cfg)
+			p.Play([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 1)
+			// the second move of the player gets the beads of a second move
+			board := [][]int{{1, 0, 0}, {0, 2, 0}, {0, 0, 0}}
+			p.Play(board, 1)
+			if m.Len() != tt.boxes {
+				t.Errorf("Matchboxes.Len() = %v, want %v", m.Len(), tt.boxes)
+			}
+			if beads, _ := m.Beads(board, 2, 2); beads != tt.beads {
+				t.Errorf("Matchboxes.Beads() = %v, want %v", beads, tt.beads)
+			}
+			p.Done(1)
+			if m.Len() != tt.boxes {
+				t.Errorf("Matchboxes.Len() after Done = %v, want %v", m.Len(), tt.boxes)
+			}
+		})
+	}
+}
+
+func TestPlayer_Learn(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	m, _ := New(e)
+	cfg := DefaultConfig()
+	cfg.Seed = 1
+	menace, _ := NewPlayer(m, "menace", cfg)
+	random := randomPlayer(e, 2)
+	// results of the first and the last 500 games, from the point of view of menace playing X
+	var first, last [3]int
+	const games = 4000
+	for k := 0; k < games; k++ {
+		g, err := game.New(e, menace, random)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for g.Play() {
+		}
+		_, winner := g.Result()
+		if k < 500 {
+			first[winner]++
+		}
+		if k >= games-500 {
+			last[winner]++
+		}
+	}
+	if menace.Games() != games {
+		t.Errorf("Player.Games() = %v, want %v", menace.Games(), games)
+	}
+	if last[2] >= first[2] || last[1] <= first[1] {
+		t.Errorf("results did not improve, first games %v, last games %v", first, last)
+	}
+}
+
+func TestMatchboxes_ReadWrite(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	m, _ := New(e)
+	p, _ := NewPlayer(m, "menace", DefaultConfig())
+	for k := 0; k < 100; k++ {
+		g, _ := game.New(e, p, p)
+		for g.Play() {
+		}
+	}
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(bytes.NewReader(buf.Bytes()), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.boxes, m.boxes) {
+		t.Error("Read() matchboxes differ from the written matchboxes")
+	}
+	other, _ := game.NewEngine(4, 4, 3)
+	if _, err := Read(bytes.NewReader(buf.Bytes()), other); err != ErrInvalidMatchboxes {
+		t.Errorf("Read() error = %v, want %v", err, ErrInvalidMatchboxes)
+	}
+}
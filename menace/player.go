@@ -0,0 +1,151 @@
+package menace
+
+import (
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/internal/episode"
+)
+
+// Config is the configuration of a matchbox player.
+type Config struct {
+	// Initial is the number of beads per move of a new matchbox by the number of moves the player
+	// has made in the game, the last value is used for all later moves.
+	Initial []int
+	// Win, Draw and Loss are the number of beads added to every move played, negative values take beads away.
+	Win, Draw, Loss int
+	// Learn is whether new matchboxes are kept and beads are reinforced at the end of each game.
+	// A player that does not learn leaves the matchboxes unchanged.
+	Learn bool
+	Seed  int64
+}
+
+// DefaultConfig returns the configuration of the original machine: 4, 3, 2 and 1 beads per move for the
+// first, second, third and fourth move, 3 beads added for a win, 1 for a draw and 1 taken away for a loss.
+func DefaultConfig() Config {
+	return Config{
+		Initial: []int{4, 3, 2, 1},
+		Win:     3,
+		Draw:    1,
+		Loss:    -1,
+		Learn:   true,
+	}
+}
+
+// Player is a player.Player that plays by drawing beads from its matchboxes.
+// When the matchbox of a position is empty the player resigns by playing an illegal move,
+// which loses the game. The same player may play both sides of a game.
+type Player struct {
+	m       *Matchboxes
+	name    string
+	cfg     Config
+	rnd     *rand.Rand
+	games   int
+	episode episode.Episode // beads drawn in the current game
+}
+
+// NewPlayer returns a new matchbox player that plays from and reinforces m.
+func NewPlayer(m *Matchboxes, name string, cfg Config) (*Player, error) {
+	if m == nil || len(cfg.Initial) == 0 {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	for _, n := range cfg.Initial {
+		if n < 1 {
+			return nil, game.ErrInvalidGameSpecs
+		}
+	}
+	return &Player{m: m, name: name, cfg: cfg, rnd: rand.New(rand.NewSource(cfg.Seed))}, nil
+}
+
+// Games returns the number of games the player has finished.
+func (p *Player) Games() int {
+	return p.games
+}
+
+// Matchboxes returns the player's matchboxes.
+func (p *Player) Matchboxes() *Matchboxes {
+	return p.m
+}
+
+// Play draws a bead from the matchbox of board and returns its move.
+// A matchbox is filled when the position is met for the first time.
+func (p *Player) Play(board [][]int, side int) (int, int) {
+	moves := p.m.e.LegalMoves(board)
+	if len(moves) == 0 || side < 1 || side > 2 {
+		return -1, -1
+	}
+	canonical, mapped := game.CanonicalMoves(board, moves)
+	k := game.Key(canonical)
+	box, ok := p.m.boxes[k]
+	if !ok {
+		n := p.episode.Moves(side)
+		if n >= len(p.cfg.Initial) {
+			n = len(p.cfg.Initial) - 1
+		}
+		box = make(map[int]int)
+		for _, c := range mapped {
+			box[c[0]*p.m.e.Columns()+c[1]] = p.cfg.Initial[n]
+		}
+		if p.cfg.Learn {
+			p.m.boxes[k] = box
+		}
+	}
+	total := 0
+	for _, beads := range box {
+		total += beads
+	}
+	if total == 0 {
+		// an empty matchbox resigns, which still counts as a move so that Done counts the game and
+		// takes beads away from the moves that led to the resignation
+		p.episode.Play(side, episode.Step{Key: k}, false)
+		return -1, -1
+	}
+	// moves that are symmetric to each other share beads, so the bead picks one of them at random
+	r := p.rnd.Intn(total)
+	var move int
+	for index := 0; index < len(k); index++ {
+		if r -= box[index]; r < 0 {
+			move = index
+			break
+		}
+	}
+	var options [][]int
+	for n, c := range mapped {
+		if c[0]*p.m.e.Columns()+c[1] == move {
+			options = append(options, moves[n])
+		}
+	}
+	p.episode.Play(side, episode.Step{Key: k, Move: move}, p.cfg.Learn)
+	m := options[p.rnd.Intn(len(options))]
+	return m[0], m[1]
+}
+
+// Done reinforces the beads drawn in the finished game.
+// When the player plays both sides Done is called twice per game, the second call does nothing.
+func (p *Player) Done(winner int) {
+	steps, ok := p.episode.End()
+	if !ok {
+		return
+	}
+	p.games++
+	for side := 1; side <= 2; side++ {
+		delta := p.cfg.Loss
+		switch winner {
+		case 0:
+			delta = p.cfg.Draw
+		case side:
+			delta = p.cfg.Win
+		}
+		for _, d := range steps[side] {
+			box := p.m.boxes[d.Key]
+			if box[d.Move] += delta; box[d.Move] < 0 {
+				box[d.Move] = 0
+			}
+		}
+	}
+}
+
+// Name returns the player name.
+func (p *Player) Name() string {
+	return p.name
+}
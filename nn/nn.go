@@ -0,0 +1,192 @@
+// Package nn is a small pure Go neural network for policy and value inference on game boards.
+//
+// A Network reads the feature planes of the dataset package and has a trunk of layers shared by two heads:
+// the policy head outputs one logit per board position and the value head outputs the expected result
+// for the side to move. Networks are loaded from and saved to JSON:
+//
+//	{
+//	  "rows": 3, "columns": 3, "target": 3,
+//	  "trunk":  [{"type": "conv", "in": 3, "out": 8, "kernel": 3, "weights": [...], "bias": [...]}, {"type": "relu"}],
+//	  "policy": [{"type": "dense", "in": 72, "out": 9, "weights": [...], "bias": [...]}],
+//	  "value":  [{"type": "dense", "in": 72, "out": 1, "weights": [...], "bias": [...]}, {"type": "tanh"}]
+//	}
+//
+// Layer types are "dense", "conv", "relu" and "tanh". Dense weights are stored output major, out x in,
+// and dense layers flatten their input. Conv layers are stride 1 convolutions with zero padding that keep
+// the board size, their weights are stored as out x in x kernel x kernel and their input must have
+// "in" planes of rows x columns. The policy head must output rows x columns values and the value head a
+// single value.
+package nn
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+)
+
+// ErrInvalidNetwork is returned when a network's layers do not fit together or the board.
+var ErrInvalidNetwork = errors.New("invalid network")
+
+// Layer is a single layer of a network.
+type Layer struct {
+	Type    string    `json:"type"`
+	In      int       `json:"in,omitempty"`
+	Out     int       `json:"out,omitempty"`
+	Kernel  int       `json:"kernel,omitempty"`
+	Weights []float32 `json:"weights,omitempty"`
+	Bias    []float32 `json:"bias,omitempty"`
+}
+
+// Network is a policy and value network for a rows x columns board.
+type Network struct {
+	Rows    int     `json:"rows"`
+	Columns int     `json:"columns"`
+	Target  int     `json:"target"`
+	Trunk   []Layer `json:"trunk"`
+	Policy  []Layer `json:"policy"`
+	Value   []Layer `json:"value"`
+}
+
+// Load reads a network in JSON format and validates it.
+func Load(r io.Reader) (*Network, error) {
+	var n Network
+	if err := json.NewDecoder(r).Decode(&n); err != nil {
+		return nil, err
+	}
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// Save writes the network in JSON format.
+func (n *Network) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(n)
+}
+
+// Inputs returns the number of input values of the network, the feature planes of a board.
+func (n *Network) Inputs() int {
+	return 3 * n.Rows * n.Columns
+}
+
+// Validate checks that the layers fit together and the heads' outputs fit the board.
+func (n *Network) Validate() error {
+	if n.Rows < 1 || n.Columns < 1 {
+		return ErrInvalidNetwork
+	}
+	planes, size, err := n.validate(n.Trunk, 3, n.Inputs())
+	if err != nil {
+		return err
+	}
+	if _, out, err := n.validate(n.Policy, planes, size); err != nil || out != n.Rows*n.Columns {
+		return ErrInvalidNetwork
+	}
+	if _, out, err := n.validate(n.Value, planes, size); err != nil || out != 1 {
+		return ErrInvalidNetwork
+	}
+	return nil
+}
+
+// validate returns the number of planes and values after layers given planes and values of input.
+// planes is 0 once the values are flattened.
+func (n *Network) validate(layers []Layer, planes, size int) (int, int, error) {
+	area := n.Rows * n.Columns
+	for _, l := range layers {
+		switch l.Type {
+		case "dense":
+			if l.In != size || l.Out < 1 || len(l.Weights) != l.In*l.Out || len(l.Bias) != l.Out {
+				return 0, 0, ErrInvalidNetwork
+			}
+			planes, size = 0, l.Out
+		case "conv":
+			if planes == 0 || l.In != planes || l.Out < 1 || l.Kernel < 1 || l.Kernel%2 == 0 ||
+				len(l.Weights) != l.Out*l.In*l.Kernel*l.Kernel || len(l.Bias) != l.Out {
+				return 0, 0, ErrInvalidNetwork
+			}
+			planes, size = l.Out, l.Out*area
+		case "relu", "tanh":
+		default:
+			return 0, 0, ErrInvalidNetwork
+		}
+	}
+	return planes, size, nil
+}
+
+// Forward returns the policy logits and the value of the input features.
+// The input must have Inputs values, such as the features of the dataset package.
+func (n *Network) Forward(input []float32) (policy []float32, value float32) {
+	x := n.forward(n.Trunk, input)
+	policy = n.forward(n.Policy, x)
+	value = n.forward(n.Value, x)[0]
+	return policy, value
+}
+
+func (n *Network) forward(layers []Layer, x []float32) []float32 {
+	for k := range layers {
+		x = n.apply(&layers[k], x)
+	}
+	return x
+}
+
+// apply returns the output of layer l for input x, x is not modified.
+func (n *Network) apply(l *Layer, x []float32) []float32 {
+	switch l.Type {
+	case "dense":
+		y := make([]float32, l.Out)
+		for o := 0; o < l.Out; o++ {
+			sum := l.Bias[o]
+			w := l.Weights[o*l.In : (o+1)*l.In]
+			for i, v := range x {
+				sum += w[i] * v
+			}
+			y[o] = sum
+		}
+		return y
+	case "conv":
+		rows, columns, k := n.Rows, n.Columns, l.Kernel
+		half := k / 2
+		area := rows * columns
+		y := make([]float32, l.Out*area)
+		for o := 0; o < l.Out; o++ {
+			for i := 0; i < rows; i++ {
+				for j := 0; j < columns; j++ {
+					sum := l.Bias[o]
+					for c := 0; c < l.In; c++ {
+						w := l.Weights[(o*l.In+c)*k*k:]
+						for a := 0; a < k; a++ {
+							r := i + a - half
+							if r < 0 || r >= rows {
+								continue
+							}
+							for b := 0; b < k; b++ {
+								s := j + b - half
+								if s < 0 || s >= columns {
+									continue
+								}
+								sum += w[a*k+b] * x[c*area+r*columns+s]
+							}
+						}
+					}
+					y[o*area+i*columns+j] = sum
+				}
+			}
+		}
+		return y
+	case "relu":
+		y := make([]float32, len(x))
+		for i, v := range x {
+			if v > 0 {
+				y[i] = v
+			}
+		}
+		return y
+	case "tanh":
+		y := make([]float32, len(x))
+		for i, v := range x {
+			y[i] = float32(math.Tanh(float64(v)))
+		}
+		return y
+	}
+	return x
+}
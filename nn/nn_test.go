@@ -0,0 +1,155 @@
+package nn
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mraufc/tictactoe/dataset"
+	"github.com/mraufc/tictactoe/game"
+)
+
+// preferNetwork returns a 3x3 network whose policy logits are prefs and whose value is tanh of the
+// number of the side to move's stones.
+func preferNetwork(prefs []float32) *Network {
+	policy := Layer{Type: "dense", In: 27, Out: 9, Weights: make([]float32, 27*9), Bias: prefs}
+	value := Layer{Type: "dense", In: 27, Out: 1, Weights: make([]float32, 27), Bias: []float32{0}}
+	for p := 0; p < 9; p++ {
+		value.Weights[p] = 1
+	}
+	return &Network{
+		Rows:    3,
+		Columns: 3,
+		Target:  3,
+		Trunk:   []Layer{{Type: "relu"}},
+		Policy:  []Layer{policy},
+		Value:   []Layer{value, {Type: "tanh"}},
+	}
+}
+
+func TestNetwork_Forward(t *testing.T) {
+	n := preferNetwork([]float32{9, 8, 7, 6, 5, 4, 3, 2, 1})
+	if err := n.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	input := make([]float32, 27)
+	policy, value := n.Forward(input)
+	if !reflect.DeepEqual(policy, []float32{9, 8, 7, 6, 5, 4, 3, 2, 1}) {
+		t.Errorf("Network.Forward() policy = %v", policy)
+	}
+	if value != 0 {
+		t.Errorf("Network.Forward() value = %v, want 0", value)
+	}
+}
+
+func TestNetwork_Conv(t *testing.T) {
+	// a 3x3 kernel that sums the neighborhood of every position of the first plane
+	kernel := make([]float32, 3*3*3)
+	for k := 0; k < 9; k++ {
+		kernel[k] = 1
+	}
+	n := &Network{
+		Rows:    3,
+		Columns: 3,
+		Target:  3,
+		Trunk:   []Layer{{Type: "conv", In: 3, Out: 1, Kernel: 3, Weights: kernel, Bias: []float32{0}}},
+		Policy:  []Layer{{Type: "relu"}},
+		Value:   []Layer{{Type: "dense", In: 9, Out: 1, Weights: make([]float32, 9), Bias: []float32{0}}},
+	}
+	if err := n.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	input := make([]float32, 27)
+	input[0], input[4] = 1, 1
+	policy, _ := n.Forward(input)
+	want := []float32{2, 2, 1, 2, 2, 1, 1, 1, 1}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("Network.Forward() = %v, want %v", policy, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	n := preferNetwork([]float32{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	var buf bytes.Buffer
+	if err := n.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, n) {
+		t.Errorf("Load() = %v, want %v", got, n)
+	}
+	invalid := `{"rows": 3, "columns": 3, "target": 3, "trunk": [{"type": "dense", "in": 5, "out": 1}], "policy": [], "value": []}`
+	if _, err := Load(strings.NewReader(invalid)); err != ErrInvalidNetwork {
+		t.Errorf("Load() error = %v, want %v", err, ErrInvalidNetwork)
+	}
+}
+
+func TestPlayer_Play(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	n := preferNetwork([]float32{9, 8, 7, 6, 5, 4, 3, 2, 1})
+	p, err := NewPlayer(e, n, "net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, j := p.Play([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 1); i != 0 || j != 0 {
+		t.Errorf("Player.Play() = %v, %v, want 0, 0", i, j)
+	}
+	// occupied positions are masked out
+	if i, j := p.Play([][]int{{1, 2, 0}, {0, 0, 0}, {0, 0, 0}}, 1); i != 0 || j != 2 {
+		t.Errorf("Player.Play() = %v, %v, want 0, 2", i, j)
+	}
+	other, _ := game.NewEngine(4, 4, 3)
+	if _, err := NewPlayer(other, n, "net"); err != ErrInvalidNetwork {
+		t.Errorf("NewPlayer() for another board error = %v, want %v", err, ErrInvalidNetwork)
+	}
+}
+
+func TestNetwork_Train(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	mlp, err := NewMLP(3, 3, 3, 16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kernel := make([]float32, 4*3*3*3)
+	for k := range kernel {
+		kernel[k] = float32(k%7-3) / 10
+	}
+	conv := &Network{
+		Rows:    3,
+		Columns: 3,
+		Target:  3,
+		Trunk:   []Layer{{Type: "conv", In: 3, Out: 4, Kernel: 3, Weights: kernel, Bias: make([]float32, 4)}, {Type: "relu"}},
+		Policy:  []Layer{{Type: "conv", In: 4, Out: 1, Kernel: 1, Weights: []float32{0.1, -0.1, 0.2, 0.1}, Bias: []float32{0}}},
+		Value:   []Layer{{Type: "dense", In: 36, Out: 1, Weights: make([]float32, 36), Bias: []float32{0}}, {Type: "tanh"}},
+	}
+	if err := conv.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// X to move should take the win on the top row
+	board := [][]int{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}}
+	sample := dataset.Sample{
+		Features: dataset.Features(e, board, 1),
+		Mask:     dataset.Mask(e, board),
+		Policy:   []float32{0, 0, 1, 0, 0, 0, 0, 0, 0},
+		Value:    1,
+	}
+	for name, n := range map[string]*Network{"mlp": mlp, "conv": conv} {
+		first := n.Train([]dataset.Sample{sample}, 0.05)
+		var last float32
+		for k := 0; k < 300; k++ {
+			last = n.Train([]dataset.Sample{sample}, 0.05)
+		}
+		if last > first/4 {
+			t.Errorf("%v Network.Train() loss went from %v to %v", name, first, last)
+		}
+		p, _ := NewPlayer(e, n, name)
+		if i, j := p.Play(board, 1); i != 0 || j != 2 {
+			t.Errorf("%v trained Player.Play() = %v, %v, want 0, 2", name, i, j)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package nn
+
+import (
+	"math"
+
+	"github.com/mraufc/tictactoe/dataset"
+	"github.com/mraufc/tictactoe/game"
+)
+
+// Player is a player.Player that plays the legal move with the highest policy logit of a network.
+type Player struct {
+	e    *game.Engine
+	n    *Network
+	name string
+}
+
+// NewPlayer returns a new network player for the engine's board. The network must be made for the
+// same board size and target.
+func NewPlayer(e *game.Engine, n *Network, name string) (*Player, error) {
+	if e == nil || n == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	if n.Rows != e.Rows() || n.Columns != e.Columns() || n.Target != e.Target() {
+		return nil, ErrInvalidNetwork
+	}
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	return &Player{e: e, n: n, name: name}, nil
+}
+
+// Play returns the legal move with the highest policy logit, occupied positions are masked out.
+func (p *Player) Play(board [][]int, side int) (int, int) {
+	policy, _ := p.n.Forward(dataset.Features(p.e, board, side))
+	best, bi, bj := float32(math.Inf(-1)), -1, -1
+	for _, m := range p.e.LegalMoves(board) {
+		if v := policy[m[0]*p.e.Columns()+m[1]]; bi < 0 || v > best {
+			best, bi, bj = v, m[0], m[1]
+		}
+	}
+	return bi, bj
+}
+
+// Done does nothing, network players do not learn from their games.
+func (p *Player) Done(winner int) {}
+
+// Name returns the player name.
+func (p *Player) Name() string {
+	return p.name
+}
@@ -0,0 +1,218 @@
+package nn
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/dataset"
+)
+
+// NewMLP returns a randomly initialized network for a rows x columns board with a single hidden dense layer
+// of the given size shared by the policy and value heads. The same seed returns the same network.
+func NewMLP(rows, columns, target, hidden int, seed int64) (*Network, error) {
+	if rows < 1 || columns < 1 || hidden < 1 {
+		return nil, ErrInvalidNetwork
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	area := rows * columns
+	n := &Network{
+		Rows:    rows,
+		Columns: columns,
+		Target:  target,
+		Trunk:   []Layer{dense(rnd, 3*area, hidden), {Type: "relu"}},
+		Policy:  []Layer{dense(rnd, hidden, area)},
+		Value:   []Layer{dense(rnd, hidden, 1), {Type: "tanh"}},
+	}
+	return n, n.Validate()
+}
+
+// dense returns a dense layer with uniform Glorot initialized weights and zero bias.
+func dense(rnd *rand.Rand, in, out int) Layer {
+	limit := math.Sqrt(6 / float64(in+out))
+	l := Layer{Type: "dense", In: in, Out: out, Weights: make([]float32, in*out), Bias: make([]float32, out)}
+	for k := range l.Weights {
+		l.Weights[k] = float32((2*rnd.Float64() - 1) * limit)
+	}
+	return l
+}
+
+// Clone returns a deep copy of the network.
+func (n *Network) Clone() *Network {
+	c := *n
+	c.Trunk = cloneLayers(n.Trunk)
+	c.Policy = cloneLayers(n.Policy)
+	c.Value = cloneLayers(n.Value)
+	return &c
+}
+
+func cloneLayers(layers []Layer) []Layer {
+	c := make([]Layer, len(layers))
+	for k, l := range layers {
+		c[k] = l
+		c[k].Weights = append([]float32(nil), l.Weights...)
+		c[k].Bias = append([]float32(nil), l.Bias...)
+	}
+	return c
+}
+
+// Train runs a single step of stochastic gradient descent with learning rate lr on a batch of samples
+// and returns the mean loss of the batch before the step. The loss is the cross entropy of the masked
+// softmax of the policy logits against the sample's policy plus the squared error of the value.
+func (n *Network) Train(batch []dataset.Sample, lr float32) float32 {
+	if len(batch) == 0 {
+		return 0
+	}
+	grads := n.Clone()
+	grads.zero()
+	var loss float32
+	for _, s := range batch {
+		trunk := n.record(n.Trunk, s.Features)
+		x := trunk[len(trunk)-1]
+		policy := n.record(n.Policy, x)
+		value := n.record(n.Value, x)
+
+		// masked softmax cross entropy
+		logits := policy[len(policy)-1]
+		probs := Softmax(logits, s.Mask)
+		gp := make([]float32, len(logits))
+		for k, p := range probs {
+			if s.Policy[k] > 0 {
+				loss -= s.Policy[k] * float32(math.Log(math.Max(float64(p), 1e-12)))
+			}
+			if s.Mask == nil || s.Mask[k] > 0 {
+				gp[k] = p - s.Policy[k]
+			}
+		}
+		// squared error of the value
+		v := value[len(value)-1][0]
+		loss += (v - s.Value) * (v - s.Value)
+		gv := []float32{2 * (v - s.Value)}
+
+		gx := n.backward(n.Policy, grads.Policy, policy, gp)
+		gxv := n.backward(n.Value, grads.Value, value, gv)
+		for k := range gx {
+			gx[k] += gxv[k]
+		}
+		n.backward(n.Trunk, grads.Trunk, trunk, gx)
+	}
+	scale := lr / float32(len(batch))
+	for _, pair := range [][2][]Layer{{n.Trunk, grads.Trunk}, {n.Policy, grads.Policy}, {n.Value, grads.Value}} {
+		for k := range pair[0] {
+			l, g := &pair[0][k], &pair[1][k]
+			for i := range l.Weights {
+				l.Weights[i] -= scale * g.Weights[i]
+			}
+			for i := range l.Bias {
+				l.Bias[i] -= scale * g.Bias[i]
+			}
+		}
+	}
+	return loss / float32(len(batch))
+}
+
+func (n *Network) zero() {
+	for _, layers := range [][]Layer{n.Trunk, n.Policy, n.Value} {
+		for k := range layers {
+			for i := range layers[k].Weights {
+				layers[k].Weights[i] = 0
+			}
+			for i := range layers[k].Bias {
+				layers[k].Bias[i] = 0
+			}
+		}
+	}
+}
+
+// record returns the input of every layer followed by the output of the last layer.
+func (n *Network) record(layers []Layer, x []float32) [][]float32 {
+	values := [][]float32{x}
+	for k := range layers {
+		x = n.apply(&layers[k], x)
+		values = append(values, x)
+	}
+	return values
+}
+
+// backward accumulates the gradients of layers' weights into grads given the recorded values of a forward
+// pass and the gradient of the output, and returns the gradient of the input.
+func (n *Network) backward(layers, grads []Layer, values [][]float32, g []float32) []float32 {
+	for k := len(layers) - 1; k >= 0; k-- {
+		l, gl := &layers[k], &grads[k]
+		x, y := values[k], values[k+1]
+		gx := make([]float32, len(x))
+		switch l.Type {
+		case "dense":
+			for o := 0; o < l.Out; o++ {
+				gl.Bias[o] += g[o]
+				w, gw := l.Weights[o*l.In:(o+1)*l.In], gl.Weights[o*l.In:(o+1)*l.In]
+				for i, v := range x {
+					gw[i] += g[o] * v
+					gx[i] += g[o] * w[i]
+				}
+			}
+		case "conv":
+			rows, columns, ks := n.Rows, n.Columns, l.Kernel
+			half, area := ks/2, rows*columns
+			for o := 0; o < l.Out; o++ {
+				for i := 0; i < rows; i++ {
+					for j := 0; j < columns; j++ {
+						gout := g[o*area+i*columns+j]
+						gl.Bias[o] += gout
+						for c := 0; c < l.In; c++ {
+							base := (o*l.In + c) * ks * ks
+							for a := 0; a < ks; a++ {
+								r := i + a - half
+								if r < 0 || r >= rows {
+									continue
+								}
+								for b := 0; b < ks; b++ {
+									s := j + b - half
+									if s < 0 || s >= columns {
+										continue
+									}
+									gl.Weights[base+a*ks+b] += gout * x[c*area+r*columns+s]
+									gx[c*area+r*columns+s] += gout * l.Weights[base+a*ks+b]
+								}
+							}
+						}
+					}
+				}
+			}
+		case "relu":
+			for i, v := range x {
+				if v > 0 {
+					gx[i] = g[i]
+				}
+			}
+		case "tanh":
+			for i := range x {
+				gx[i] = g[i] * (1 - y[i]*y[i])
+			}
+		}
+		g = gx
+	}
+	return g
+}
+
+// Softmax returns the probabilities of logits over the positions of mask that are not 0, a nil mask allows all.
+func Softmax(logits, mask []float32) []float32 {
+	probs := make([]float32, len(logits))
+	top := float32(math.Inf(-1))
+	for k, v := range logits {
+		if (mask == nil || mask[k] > 0) && v > top {
+			top = v
+		}
+	}
+	var sum float64
+	for k, v := range logits {
+		if mask == nil || mask[k] > 0 {
+			e := math.Exp(float64(v - top))
+			probs[k] = float32(e)
+			sum += e
+		}
+	}
+	for k := range probs {
+		probs[k] = float32(float64(probs[k]) / sum)
+	}
+	return probs
+}
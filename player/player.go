@@ -11,3 +11,49 @@ type Player interface {
 	// Name returns the player name / id.
 	Name() string
 }
+
+// Player3D is the three dimensional TicTacToe variation player
+type Player3D interface {
+	// Play returns a position to play given the board and player side.
+	// board is indexed as board[row][column][depth].
+	Play(board [][][]int, side int) (int, int, int)
+	// Done informs the player that the current game is over.
+	// winner 0 means the game is a tie, 1 means player 1 won and 2 means player 2 won.
+	Done(winner int)
+	// Name returns the player name / id.
+	Name() string
+}
+
+// UltimatePlayer is the Ultimate TicTacToe (nested boards) variation player
+type UltimatePlayer interface {
+	// Play returns a position to play given the whole board, the meta board and player side.
+	// board holds every local board side by side and the returned position is in board coordinates.
+	// meta holds the state of each local board: 0 is open, 1 is won by X, 2 is won by O and 3 is drawn.
+	// bi, bj is the local board that must be played, or -1, -1 when any open local board can be played.
+	Play(board [][]int, meta [][]int, bi, bj, side int) (int, int)
+	// Done informs the player that the current game is over.
+	// winner 0 means the game is a tie, 1 means player 1 won and 2 means player 2 won.
+	Done(winner int)
+	// Name returns the player name / id.
+	Name() string
+}
+
+// MultiPlayer is a player for variants where a turn may consist of several placements,
+// such as Connect6.
+type MultiPlayer interface {
+	Player
+	// PlayMulti returns count positions to play given the board and player side.
+	// Positions are placed in the returned order.
+	PlayMulti(board [][]int, side, count int) [][]int
+}
+
+// OpeningPlayer is a player that can take part in opening protocols such as the pie rule, swap and swap2.
+type OpeningPlayer interface {
+	Player
+	// Place returns a position for each stone of the given sides, in order, during an opening.
+	Place(board [][]int, sides []int) [][]int
+	// Choose returns the side the player wants to play given the opening position, it must be one of options.
+	// 1 means X and 2 means O. 0 is only offered by swap2 and means placing one more O and one more X
+	// and letting the opponent choose instead.
+	Choose(board [][]int, options []int) int
+}
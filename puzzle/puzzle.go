@@ -0,0 +1,280 @@
+// Package puzzle generates and checks "side to move wins in N moves" puzzles for the game package.
+// A puzzle's solution is a first move after which the side to move wins within N of its own moves
+// against every defense. Searches are bounded exhaustive searches, so they are meant for small boards
+// and small N.
+package puzzle
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// ErrInvalidMoves is returned when the number of moves of a puzzle is less than 1.
+var ErrInvalidMoves = errors.New("invalid number of moves")
+
+// ErrNotEnoughPuzzles is returned when a generator can not find the requested number of puzzles.
+var ErrNotEnoughPuzzles = errors.New("not enough puzzles found")
+
+// Puzzle is a position where the side to move has a forced win in exactly Moves moves.
+type Puzzle struct {
+	Rows     int     `json:"rows"`
+	Columns  int     `json:"columns"`
+	Target   int     `json:"target"`
+	Board    [][]int `json:"board"`
+	Side     int     `json:"side"`
+	Moves    int     `json:"moves"`
+	Solution [][]int `json:"solution"` // every first move that wins within Moves moves
+}
+
+// Solve returns every first move of the side to move that forces a win within n of its own moves.
+func Solve(e *game.Engine, board [][]int, n int) ([][]int, error) {
+	side, err := setup(e, board, n)
+	if err != nil {
+		return nil, err
+	}
+	s := &solver{e: e, board: game.CopyBoard(board)}
+	var solution [][]int
+	for _, m := range s.e.LegalMoves(s.board) {
+		if s.wins(side, m[0], m[1]) {
+			solution = append(solution, m)
+			continue
+		}
+		if n == 1 {
+			continue
+		}
+		s.board[m[0]][m[1]] = side
+		if s.defend(side, n-1) {
+			solution = append(solution, m)
+		}
+		s.board[m[0]][m[1]] = 0
+	}
+	return solution, nil
+}
+
+// Check returns whether the side to move playing i, j wins within n of its own moves against all defenses.
+func Check(e *game.Engine, board [][]int, n, i, j int) (bool, error) {
+	side, err := setup(e, board, n)
+	if err != nil {
+		return false, err
+	}
+	s := &solver{e: e, board: game.CopyBoard(board)}
+	if !e.Legal(s.board, i, j) {
+		return false, nil
+	}
+	if s.wins(side, i, j) {
+		return true, nil
+	}
+	if n == 1 {
+		return false, nil
+	}
+	s.board[i][j] = side
+	return s.defend(side, n-1), nil
+}
+
+// Exact returns whether the side to move has a forced win in exactly n moves, that is,
+// within n moves but not within n - 1 moves.
+func Exact(e *game.Engine, board [][]int, n int) (bool, error) {
+	side, err := setup(e, board, n)
+	if err != nil {
+		return false, err
+	}
+	s := &solver{e: e, board: game.CopyBoard(board)}
+	if n > 1 && s.win(side, n-1) {
+		return false, nil
+	}
+	return s.win(side, n), nil
+}
+
+func setup(e *game.Engine, board [][]int, n int) (int, error) {
+	if e == nil {
+		return 0, game.ErrInvalidGameSpecs
+	}
+	if n < 1 {
+		return 0, ErrInvalidMoves
+	}
+	return e.Validate(board)
+}
+
+// Generator generates puzzles from random positions on an engine's board.
+type Generator struct {
+	e   *game.Engine
+	rnd *rand.Rand
+}
+
+// NewGenerator returns a new puzzle generator. The same seed generates the same puzzles.
+func NewGenerator(e *game.Engine, seed int64) (*Generator, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Generator{e: e, rnd: rand.New(rand.NewSource(seed))}, nil
+}
+
+// Generate tries up to attempts random positions and returns up to count distinct puzzles with a forced
+// win in exactly n moves. If fewer than count puzzles are found, the puzzles found so far are returned
+// together with ErrNotEnoughPuzzles.
+func (g *Generator) Generate(n, count, attempts int) ([]Puzzle, error) {
+	if n < 1 {
+		return nil, ErrInvalidMoves
+	}
+	seen := make(map[string]bool)
+	var puzzles []Puzzle
+	for a := 0; a < attempts && len(puzzles) < count; a++ {
+		board, ok := g.position()
+		if !ok {
+			continue
+		}
+		key := game.Key(board)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		exact, err := Exact(g.e, board, n)
+		if err != nil || !exact {
+			continue
+		}
+		side, _ := g.e.Validate(board)
+		solution, _ := Solve(g.e, board, n)
+		puzzles = append(puzzles, Puzzle{
+			Rows:     g.e.Rows(),
+			Columns:  g.e.Columns(),
+			Target:   g.e.Target(),
+			Board:    board,
+			Side:     side,
+			Moves:    n,
+			Solution: solution,
+		})
+	}
+	if len(puzzles) < count {
+		return puzzles, ErrNotEnoughPuzzles
+	}
+	return puzzles, nil
+}
+
+// position plays a random number of random moves next to existing symbols.
+// It returns false if the game is over before the last move.
+func (g *Generator) position() ([][]int, bool) {
+	rows, columns := g.e.Rows(), g.e.Columns()
+	board := make([][]int, rows)
+	for i := range board {
+		board[i] = make([]int, columns)
+	}
+	board[g.rnd.Intn(rows)][g.rnd.Intn(columns)] = 1
+	plies := 1 + g.rnd.Intn(rows*columns/2)
+	for ply := 1; ply <= plies; ply++ {
+		var candidates [][]int
+		for i := 0; i < rows; i++ {
+			for j := 0; j < columns; j++ {
+				if board[i][j] == 0 && neighbor(board, i, j) {
+					candidates = append(candidates, []int{i, j})
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, false
+		}
+		m := candidates[g.rnd.Intn(len(candidates))]
+		side := 1 + ply%2
+		gameOver, _, err := g.e.Evaluate(board, side, m[0], m[1])
+		if err != nil || gameOver {
+			return nil, false
+		}
+		board[m[0]][m[1]] = side
+	}
+	return board, true
+}
+
+func neighbor(board [][]int, i, j int) bool {
+	for a := i - 1; a <= i+1; a++ {
+		for b := j - 1; b <= j+1; b++ {
+			if a >= 0 && b >= 0 && a < len(board) && b < len(board[a]) && board[a][b] != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Write writes puzzles to w as a JSON array.
+func Write(w io.Writer, puzzles []Puzzle) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(puzzles)
+}
+
+// Read reads puzzles written by Write.
+func Read(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	if err := json.NewDecoder(r).Decode(&puzzles); err != nil {
+		return nil, err
+	}
+	return puzzles, nil
+}
+
+// solver is a bounded exhaustive search over a single board that is modified in place.
+type solver struct {
+	e     *game.Engine
+	board [][]int
+}
+
+// win returns whether side, to move, forces a win within k of its own moves.
+func (s *solver) win(side, k int) bool {
+	if len(s.e.WinningMoves(s.board, side)) > 0 {
+		return true
+	}
+	if k == 1 {
+		return false
+	}
+	for _, m := range s.e.LegalMoves(s.board) {
+		s.board[m[0]][m[1]] = side
+		ok := s.defend(side, k-1)
+		s.board[m[0]][m[1]] = 0
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// defend returns whether attacker still forces a win within k of its own moves after any defense.
+func (s *solver) defend(attacker, k int) bool {
+	defender := 3 - attacker
+	if len(s.e.WinningMoves(s.board, defender)) > 0 {
+		return false
+	}
+	moves := s.e.BlockingMoves(s.board, defender)
+	if len(moves) >= 2 {
+		return true
+	}
+	if len(moves) == 0 {
+		if k == 1 {
+			return false
+		}
+		moves = s.e.LegalMoves(s.board)
+	}
+	if len(moves) == 0 {
+		return false
+	}
+	for _, m := range moves {
+		s.board[m[0]][m[1]] = defender
+		ok := s.win(attacker, k)
+		s.board[m[0]][m[1]] = 0
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// wins returns whether side playing i, j completes a line.
+func (s *solver) wins(side, i, j int) bool {
+	for _, m := range s.e.WinningMoves(s.board, side) {
+		if m[0] == i && m[1] == j {
+			return true
+		}
+	}
+	return false
+}
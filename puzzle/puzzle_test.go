@@ -0,0 +1,154 @@
+package puzzle
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func TestSolve(t *testing.T) {
+	e3, _ := game.NewEngine(3, 3, 3)
+	e5, _ := game.NewEngine(5, 5, 4)
+	tests := []struct {
+		name    string
+		e       *game.Engine
+		board   [][]int
+		n       int
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "X wins in 1",
+			e:    e3,
+			board: [][]int{
+				{1, 1, 0},
+				{2, 2, 0},
+				{0, 0, 0},
+			},
+			n:    1,
+			want: [][]int{{0, 2}},
+		},
+		{
+			name: "O has no win in 1",
+			e:    e3,
+			board: [][]int{
+				{1, 1, 2},
+				{2, 0, 0},
+				{0, 0, 1},
+			},
+			n:    1,
+			want: nil,
+		},
+		{
+			name: "X wins in 2 with an open three",
+			e:    e5,
+			board: [][]int{
+				{0, 0, 0, 0, 0},
+				{2, 0, 0, 0, 2},
+				{0, 1, 1, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 2, 1},
+			},
+			n:    2,
+			want: [][]int{{2, 3}},
+		},
+		{
+			name:    "invalid number of moves",
+			e:       e3,
+			board:   [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			n:       0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid board",
+			e:       e3,
+			board:   [][]int{{2, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			n:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(tt.e, tt.board, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Solve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Solve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	// X in the center and O on an edge, X wins by force
+	board := [][]int{
+		{0, 2, 0},
+		{0, 1, 0},
+		{0, 0, 0},
+	}
+	tests := []struct {
+		name string
+		n    int
+		i, j int
+		want bool
+	}{
+		{name: "corner next to O wins in 3", n: 3, i: 0, j: 0, want: true},
+		{name: "corner next to O does not win in 2", n: 2, i: 0, j: 0, want: false},
+		{name: "occupied position", n: 3, i: 0, j: 1, want: false},
+		{name: "outside of the board", n: 3, i: 3, j: 0, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Check(e, board, tt.n, tt.i, tt.j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	e, _ := game.NewEngine(4, 4, 3)
+	g, err := NewGenerator(e, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	puzzles, err := g.Generate(2, 3, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range puzzles {
+		exact, err := Exact(e, p.Board, p.Moves)
+		if err != nil || !exact {
+			t.Errorf("Generate() puzzle %v is not a win in exactly %v moves", p.Board, p.Moves)
+		}
+		if len(p.Solution) == 0 {
+			t.Errorf("Generate() puzzle %v has no solution", p.Board)
+		}
+		for _, m := range p.Solution {
+			if ok, _ := Check(e, p.Board, p.Moves, m[0], m[1]); !ok {
+				t.Errorf("Generate() puzzle %v solution %v does not check", p.Board, m)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, puzzles); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, puzzles) {
+		t.Errorf("Read() = %v, want %v", got, puzzles)
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+// glyphs is a 3x5 pixel font for digits and lower case letters, which is all the text the images need.
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", ".##", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'a': {".#.", "#.#", "###", "#.#", "#.#"},
+	'b': {"##.", "#.#", "##.", "#.#", "##."},
+	'c': {".##", "#..", "#..", "#..", ".##"},
+	'd': {"##.", "#.#", "#.#", "#.#", "##."},
+	'e': {"###", "#..", "##.", "#..", "###"},
+	'f': {"###", "#..", "##.", "#..", "#.."},
+	'g': {".##", "#..", "#.#", "#.#", ".##"},
+	'h': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'i': {"###", ".#.", ".#.", ".#.", "###"},
+	'j': {"..#", "..#", "..#", "#.#", ".#."},
+	'k': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'l': {"#..", "#..", "#..", "#..", "###"},
+	'm': {"#.#", "###", "###", "#.#", "#.#"},
+	'n': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'o': {".#.", "#.#", "#.#", "#.#", ".#."},
+	'p': {"##.", "#.#", "##.", "#..", "#.."},
+	'q': {".#.", "#.#", "#.#", "##.", ".##"},
+	'r': {"##.", "#.#", "##.", "#.#", "#.#"},
+	's': {".##", "#..", ".#.", "..#", "##."},
+	't': {"###", ".#.", ".#.", ".#.", ".#."},
+	'u': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'v': {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'w': {"#.#", "#.#", "###", "###", "#.#"},
+	'x': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'z': {"###", "..#", ".#.", "#..", "###"},
+}
+
+// textWidth returns the width in pixels of s drawn with pixels of size scale.
+func textWidth(s string, scale int) int {
+	if len(s) == 0 {
+		return 0
+	}
+	return (4*len(s) - 1) * scale
+}
@@ -0,0 +1,77 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// palette holds every color of the images.
+var palette = color.Palette{
+	rgb(background), rgb(grid), rgb(colorX), rgb(colorO), rgb(blocked), rgb(highlight), rgb(label),
+}
+
+// GIF writes an animated replay of b to w: the board before the first move and after every move of b.Moves,
+// each shown for delay and the last one for three times as long. The winning line is only shown on the
+// last frame. Positions of the board that are not played by b.Moves are on every frame.
+func GIF(w io.Writer, b Board, o Options, delay time.Duration) error {
+	frames, err := b.replay()
+	if err != nil {
+		return err
+	}
+	hundredths := int(delay / (10 * time.Millisecond))
+	anim := &gif.GIF{}
+	for k, frame := range frames {
+		if k < len(frames)-1 {
+			// the winning line belongs to the final position only
+			frame.Line = nil
+		}
+		img, err := Image(frame, o)
+		if err != nil {
+			return err
+		}
+		p := image.NewPaletted(img.Bounds(), palette)
+		draw.Draw(p, p.Bounds(), img, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, p)
+		if k == len(frames)-1 {
+			anim.Delay = append(anim.Delay, 3*hundredths)
+		} else {
+			anim.Delay = append(anim.Delay, hundredths)
+		}
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// replay returns the position before the first move and after every move of b.Moves.
+func (b Board) replay() ([]Board, error) {
+	if err := validate(b); err != nil {
+		return nil, err
+	}
+	start := make([][]int, len(b.Board))
+	for i, row := range b.Board {
+		start[i] = append([]int(nil), row...)
+	}
+	for _, m := range b.Moves {
+		if len(m) != 2 || b.at(m[0], m[1]) != 1 && b.at(m[0], m[1]) != 2 || start[m[0]][m[1]] == 0 {
+			// every move must be a distinct played position of the board
+			return nil, game.ErrIllegalMove
+		}
+		start[m[0]][m[1]] = 0
+	}
+	frames := []Board{{Board: start, Line: b.Line}}
+	for k, m := range b.Moves {
+		prev := frames[len(frames)-1].Board
+		board := make([][]int, len(prev))
+		for i, row := range prev {
+			board[i] = append([]int(nil), row...)
+		}
+		board[m[0]][m[1]] = b.Board[m[0]][m[1]]
+		frames = append(frames, Board{Board: board, Moves: b.Moves[:k+1], Line: b.Line})
+	}
+	return frames, nil
+}
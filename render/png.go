@@ -0,0 +1,169 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// PNG writes b to w as a PNG image.
+func PNG(w io.Writer, b Board, o Options) error {
+	img, err := Image(b, o)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Image draws b.
+func Image(b Board, o Options) (*image.RGBA, error) {
+	if err := validate(b); err != nil {
+		return nil, err
+	}
+	l := newLayout(len(b.Board), len(b.Board[0]), o)
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(rgb(background)), image.Point{}, draw.Src)
+
+	for i, row := range b.Board {
+		for j, v := range row {
+			if v == game.Blocked {
+				r := image.Rect(l.left+j*l.cell, l.top+i*l.cell, l.left+(j+1)*l.cell, l.top+(i+1)*l.cell)
+				draw.Draw(img, r, image.NewUniform(rgb(blocked)), image.Point{}, draw.Src)
+			}
+		}
+	}
+	for i := 0; i <= l.rows; i++ {
+		r := image.Rect(l.left-1, l.top+i*l.cell-1, l.left+l.columns*l.cell+1, l.top+i*l.cell+1)
+		draw.Draw(img, r, image.NewUniform(rgb(grid)), image.Point{}, draw.Src)
+	}
+	for j := 0; j <= l.columns; j++ {
+		r := image.Rect(l.left+j*l.cell-1, l.top-1, l.left+j*l.cell+1, l.top+l.rows*l.cell+1)
+		draw.Draw(img, r, image.NewUniform(rgb(grid)), image.Point{}, draw.Src)
+	}
+	if line := b.Line; line != nil {
+		x1, y1 := l.center(line.Start[0], line.Start[1])
+		x2, y2 := l.center(line.End[0], line.End[1])
+		segment(img, x1, y1, x2, y2, float64(l.cell)/8, rgb(highlight))
+	}
+
+	stroke := float64(l.cell) / 24
+	if stroke < 1 {
+		stroke = 1
+	}
+	r := l.cell * 3 / 10
+	for i, row := range b.Board {
+		for j, v := range row {
+			x, y := l.center(i, j)
+			switch v {
+			case 1:
+				segment(img, x-r, y-r, x+r, y+r, stroke, rgb(colorX))
+				segment(img, x+r, y-r, x-r, y+r, stroke, rgb(colorX))
+			case 2:
+				ring(img, x, y, float64(r), stroke, rgb(colorO))
+			}
+		}
+	}
+
+	scale := l.cell / 24
+	if scale < 1 {
+		scale = 1
+	}
+	if o.MoveNumbers {
+		for i, row := range b.numbers() {
+			for j, n := range row {
+				if n > 0 {
+					text(img, strconv.Itoa(n), l.left+j*l.cell+3, l.top+i*l.cell+3, scale, rgb(label))
+				}
+			}
+		}
+	}
+	if o.Coordinates {
+		for j := 0; j < l.columns; j++ {
+			s := game.ColumnLabel(j)
+			x, _ := l.center(0, j)
+			text(img, s, x-textWidth(s, scale)/2, (l.top-5*scale)/2, scale, rgb(label))
+		}
+		for i := 0; i < l.rows; i++ {
+			s := strconv.Itoa(i + 1)
+			_, y := l.center(i, 0)
+			text(img, s, (l.left-textWidth(s, scale))/2, y-5*scale/2, scale, rgb(label))
+		}
+	}
+	return img, nil
+}
+
+func rgb(c int) color.RGBA {
+	return color.RGBA{R: uint8(c >> 16), G: uint8(c >> 8), B: uint8(c), A: 0xff}
+}
+
+// segment draws a line from x1, y1 to x2, y2 with round ends that is width pixels wide.
+func segment(img *image.RGBA, x1, y1, x2, y2 int, width float64, c color.RGBA) {
+	h := width / 2
+	bounds := image.Rect(minInt(x1, x2)-int(h)-1, minInt(y1, y2)-int(h)-1, maxInt(x1, x2)+int(h)+2, maxInt(y1, y2)+int(h)+2)
+	dx, dy := float64(x2-x1), float64(y2-y1)
+	length := dx*dx + dy*dy
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px, py := float64(x-x1), float64(y-y1)
+			t := 0.0
+			if length > 0 {
+				t = math.Max(0, math.Min(1, (px*dx+py*dy)/length))
+			}
+			if ex, ey := px-t*dx, py-t*dy; ex*ex+ey*ey <= h*h {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// ring draws a circle around x, y with radius r that is width pixels wide.
+func ring(img *image.RGBA, x, y int, r, width float64, c color.RGBA) {
+	outer := int(r+width) + 1
+	for py := y - outer; py <= y+outer; py++ {
+		for px := x - outer; px <= x+outer; px++ {
+			dx, dy := float64(px-x), float64(py-y)
+			if d := math.Sqrt(dx*dx + dy*dy); math.Abs(d-r) <= width/2 {
+				img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
+
+// text draws s with its top left corner at x, y in the pixel font of glyphs.
+func text(img *image.RGBA, s string, x, y, scale int, c color.RGBA) {
+	for _, ch := range s {
+		g := glyphs[ch]
+		for row, bits := range g {
+			for col, bit := range bits {
+				if bit != '#' {
+					continue
+				}
+				r := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+			}
+		}
+		x += 4 * scale
+	}
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,122 @@
+// Package render draws boards and games as SVG and PNG images, and replays games as animated GIFs.
+//
+// An image shows the grid, the X's, O's and Blocked positions, and optionally the coordinates in the
+// letter and number format of game.Format, the number of every move and the winning line. A GIF
+// replay shows the board after every move of a game, one frame per move.
+package render
+
+import (
+	"github.com/mraufc/tictactoe/game"
+)
+
+// Options configure the images.
+type Options struct {
+	Cell        int  // size of a position in pixels
+	Coordinates bool // column letters above and row numbers left of the board
+	MoveNumbers bool // number of the move in the corner of every played position
+}
+
+// DefaultOptions returns options for 48 pixel positions with coordinates and move numbers.
+func DefaultOptions() Options {
+	return Options{Cell: 48, Coordinates: true, MoveNumbers: true}
+}
+
+// Colors of the images.
+const (
+	background = 0xffffff
+	grid       = 0x424242
+	colorX     = 0xd32f2f
+	colorO     = 0x1976d2
+	blocked    = 0x9e9e9e
+	highlight  = 0xffc107
+	label      = 0x757575
+)
+
+// layout is the geometry of an image.
+type layout struct {
+	rows, columns int
+	cell          int
+	left, top     int // position of the board's top left corner
+	width, height int
+}
+
+func newLayout(rows, columns int, o Options) layout {
+	cell := o.Cell
+	if cell < 16 {
+		cell = 16
+	}
+	margin := cell / 8
+	l := layout{rows: rows, columns: columns, cell: cell, left: margin, top: margin}
+	if o.Coordinates {
+		l.left, l.top = cell/2+margin, cell/2
+	}
+	l.width = l.left + columns*cell + margin
+	l.height = l.top + rows*cell + margin
+	return l
+}
+
+// center returns the pixel coordinates of the center of position i, j.
+func (l layout) center(i, j int) (int, int) {
+	return l.left + j*l.cell + l.cell/2, l.top + i*l.cell + l.cell/2
+}
+
+// Board is a position to draw.
+type Board struct {
+	// Board is the position, rows of 0 for empty, 1 for X, 2 for O and game.Blocked.
+	Board [][]int
+	// Moves are the positions played in order, used for move numbers. Moves may be nil.
+	Moves [][]int
+	// Line is the winning line to highlight, such as the line of Engine.WinningLine. Line may be nil.
+	Line *game.Line
+}
+
+// Game returns the position of a game of TicTacToe with its moves and winning line.
+func Game(g *game.TicTacToe) Board {
+	return Board{Board: g.Board(), Moves: g.History(), Line: g.WinningLine()}
+}
+
+// numbers returns the move number of every position, 0 for positions that were not played.
+func (b Board) numbers() [][]int {
+	numbers := make([][]int, len(b.Board))
+	for i, row := range b.Board {
+		numbers[i] = make([]int, len(row))
+	}
+	for n, m := range b.Moves {
+		if len(m) == 2 && m[0] >= 0 && m[0] < len(numbers) && m[1] >= 0 && m[1] < len(numbers[m[0]]) {
+			numbers[m[0]][m[1]] = n + 1
+		}
+	}
+	return numbers
+}
+
+// at returns the value of position i, j, or -1 if it is outside of the board.
+func (b Board) at(i, j int) int {
+	if i < 0 || i >= len(b.Board) || j < 0 || j >= len(b.Board[i]) {
+		return -1
+	}
+	return b.Board[i][j]
+}
+
+func validate(b Board) error {
+	if len(b.Board) == 0 || len(b.Board[0]) == 0 {
+		return game.ErrInvalidBoard
+	}
+	for _, row := range b.Board {
+		if len(row) != len(b.Board[0]) {
+			return game.ErrInvalidBoard
+		}
+		for _, v := range row {
+			if v < 0 || v > game.Blocked {
+				return game.ErrInvalidBoard
+			}
+		}
+	}
+	if l := b.Line; l != nil {
+		for _, p := range [][]int{l.Start, l.End} {
+			if len(p) != 2 || p[0] < 0 || p[1] < 0 || p[0] >= len(b.Board) || p[1] >= len(b.Board[0]) {
+				return game.ErrInvalidBoard
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/game/gametest"
+)
+
+// playGame plays X at the top row and O at the second row of a 4x7 board until X wins.
+func playGame(t *testing.T) *game.TicTacToe {
+	e, _ := game.NewEngine(4, 7, 3)
+	x := gametest.NewScripted([][]int{{0, 0}, {0, 1}, {0, 2}}, "X")
+	o := gametest.NewScripted([][]int{{1, 0}, {1, 1}}, "O")
+	g, err := game.New(e, x, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gametest.Run(g)
+	return g
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, Game(playGame(t)), DefaultOptions()); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	counts := map[string]int{
+		"<svg ":                1,
+		"<path ":               3,
+		"<circle ":             2,
+		`class="winning-line"`: 1,
+		"<text ":               5 + 7 + 4,
+		">5</text>":            1,
+		">g</text>":            1,
+	}
+	for s, want := range counts {
+		if got := strings.Count(svg, s); got != want {
+			t.Errorf("SVG() has %v of %q, want %v", got, s, want)
+		}
+	}
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="372" height="222"`) {
+		t.Errorf("SVG() = %v", svg[:80])
+	}
+
+	buf.Reset()
+	if err := SVG(&buf, Board{Board: [][]int{{0, 4}}}, DefaultOptions()); err != game.ErrInvalidBoard {
+		t.Errorf("SVG() error = %v, want %v", err, game.ErrInvalidBoard)
+	}
+	for _, line := range []*game.Line{{}, {Start: []int{0, 0}, End: []int{0, 2}}} {
+		if err := SVG(&buf, Board{Board: [][]int{{1, 1}}, Line: line}, DefaultOptions()); err != game.ErrInvalidBoard {
+			t.Errorf("SVG() with line %+v error = %v, want %v", line, err, game.ErrInvalidBoard)
+		}
+		if err := PNG(&buf, Board{Board: [][]int{{1, 1}}, Line: line}, DefaultOptions()); err != game.ErrInvalidBoard {
+			t.Errorf("PNG() with line %+v error = %v, want %v", line, err, game.ErrInvalidBoard)
+		}
+	}
+}
+
+func TestPNG(t *testing.T) {
+	o := DefaultOptions()
+	var buf bytes.Buffer
+	if err := PNG(&buf, Game(playGame(t)), o); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := newLayout(4, 7, o)
+	if b := img.Bounds(); b.Dx() != l.width || b.Dy() != l.height {
+		t.Fatalf("PNG() size = %v, want %vx%v", b, l.width, l.height)
+	}
+	x, y := l.center(2, 3)
+	if got := rgbAt(img, x, y); got != background {
+		t.Errorf("empty position color = %06x, want %06x", got, background)
+	}
+	// the center of X at 0, 0 is covered by the winning line, O's ring is r away from its center
+	x, y = l.center(0, 0)
+	if got := rgbAt(img, x, y); got != colorX {
+		t.Errorf("X position color = %06x, want %06x", got, colorX)
+	}
+	x, y = l.center(0, 1)
+	if got := rgbAt(img, x, y+2); got != highlight {
+		t.Errorf("winning line color = %06x, want %06x", got, highlight)
+	}
+	x, y = l.center(1, 0)
+	if got := rgbAt(img, x+l.cell*3/10, y); got != colorO {
+		t.Errorf("O position color = %06x, want %06x", got, colorO)
+	}
+}
+
+func rgbAt(img image.Image, x, y int) int {
+	r, g, b, _ := img.At(x, y).RGBA()
+	return int(r>>8)<<16 | int(g>>8)<<8 | int(b>>8)
+}
+
+func TestGIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GIF(&buf, Game(playGame(t)), DefaultOptions(), 500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 6 || !reflect.DeepEqual(anim.Delay, []int{50, 50, 50, 50, 50, 150}) {
+		t.Fatalf("GIF() has %v frames with delays %v, want 6 frames", len(anim.Image), anim.Delay)
+	}
+	l := newLayout(4, 7, DefaultOptions())
+	// X plays 0, 1 with the third move and the winning line goes through it on the last frame
+	x, y := l.center(0, 1)
+	centers := []int{background, background, background, colorX, colorX, colorX}
+	lines := []int{background, background, background, background, background, highlight}
+	for k, img := range anim.Image {
+		if got := rgbAt(img, x, y); got != centers[k] {
+			t.Errorf("frame %v center color = %06x, want %06x", k, got, centers[k])
+		}
+		if got := rgbAt(img, x, y+2); got != lines[k] {
+			t.Errorf("frame %v line color = %06x, want %06x", k, got, lines[k])
+		}
+	}
+
+	b := Game(playGame(t))
+	b.Moves = append(b.Moves, []int{0, 0})
+	if err := GIF(&buf, b, DefaultOptions(), time.Second); err != game.ErrIllegalMove {
+		t.Errorf("GIF() error = %v, want %v", err, game.ErrIllegalMove)
+	}
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// SVG writes b to w as an SVG image.
+func SVG(w io.Writer, b Board, o Options) error {
+	if err := validate(b); err != nil {
+		return err
+	}
+	l := newLayout(len(b.Board), len(b.Board[0]), o)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="#%06x"/>`+"\n", l.width, l.height, background)
+
+	stroke := l.cell / 12
+	if stroke < 1 {
+		stroke = 1
+	}
+	for i, row := range b.Board {
+		for j, v := range row {
+			if v == game.Blocked {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%06x"/>`+"\n",
+					l.left+j*l.cell, l.top+i*l.cell, l.cell, l.cell, blocked)
+			}
+		}
+	}
+	for i := 0; i <= l.rows; i++ {
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#%06x" stroke-width="2"/>`+"\n",
+			l.left, l.top+i*l.cell, l.left+l.columns*l.cell, l.top+i*l.cell, grid)
+	}
+	for j := 0; j <= l.columns; j++ {
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#%06x" stroke-width="2"/>`+"\n",
+			l.left+j*l.cell, l.top, l.left+j*l.cell, l.top+l.rows*l.cell, grid)
+	}
+	if line := b.Line; line != nil {
+		x1, y1 := l.center(line.Start[0], line.Start[1])
+		x2, y2 := l.center(line.End[0], line.End[1])
+		fmt.Fprintf(bw, `<line class="winning-line" x1="%d" y1="%d" x2="%d" y2="%d" stroke="#%06x" stroke-width="%d" stroke-linecap="round" opacity="0.8"/>`+"\n",
+			x1, y1, x2, y2, highlight, l.cell/4)
+	}
+
+	r := l.cell * 3 / 10
+	for i, row := range b.Board {
+		for j, v := range row {
+			x, y := l.center(i, j)
+			switch v {
+			case 1:
+				fmt.Fprintf(bw, `<path d="M%d %dL%d %dM%d %dL%d %d" stroke="#%06x" stroke-width="%d" stroke-linecap="round"/>`+"\n",
+					x-r, y-r, x+r, y+r, x+r, y-r, x-r, y+r, colorX, stroke)
+			case 2:
+				fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="#%06x" stroke-width="%d"/>`+"\n",
+					x, y, r, colorO, stroke)
+			}
+		}
+	}
+
+	font := l.cell / 4
+	if o.MoveNumbers {
+		for i, row := range b.numbers() {
+			for j, n := range row {
+				if n > 0 {
+					fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" fill="#%06x">%d</text>`+"\n",
+						l.left+j*l.cell+3, l.top+i*l.cell+font+1, font, label, n)
+				}
+			}
+		}
+	}
+	if o.Coordinates {
+		for j := 0; j < l.columns; j++ {
+			x, _ := l.center(0, j)
+			fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" text-anchor="middle" fill="#%06x">%s</text>`+"\n",
+				x, l.top-font/2, font, label, game.ColumnLabel(j))
+		}
+		for i := 0; i < l.rows; i++ {
+			_, y := l.center(i, 0)
+			fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" text-anchor="middle" fill="#%06x">%s</text>`+"\n",
+				l.left/2, y+font/3, font, label, strconv.Itoa(i+1))
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,138 @@
+package rl
+
+import (
+	"math/rand"
+
+	"github.com/mraufc/tictactoe/game"
+	"github.com/mraufc/tictactoe/internal/episode"
+)
+
+// Schedule is an exploration schedule. The probability of playing a random move instead of the best
+// known move decreases linearly from Start to End over the first Games games and stays at End afterwards.
+type Schedule struct {
+	Start float64
+	End   float64
+	Games int
+}
+
+// Epsilon returns the probability of a random move in game number games, counted from 0.
+func (s Schedule) Epsilon(games int) float64 {
+	if games >= s.Games {
+		return s.End
+	}
+	return s.Start + (s.End-s.Start)*float64(games)/float64(s.Games)
+}
+
+// Config is the configuration of a learning player.
+type Config struct {
+	Alpha       float64  // learning rate
+	Gamma       float64  // discount of the value of the next position
+	Exploration Schedule // probability of random moves
+	Learn       bool     // whether the table is updated at the end of each game
+	Seed        int64
+}
+
+// DefaultConfig returns a configuration that learns 3x3 TicTacToe in a few tens of thousands of games.
+func DefaultConfig() Config {
+	return Config{
+		Alpha:       0.3,
+		Gamma:       0.95,
+		Exploration: Schedule{Start: 1, End: 0.05, Games: 20000},
+		Learn:       true,
+	}
+}
+
+// Player is a player.Player that plays the move with the highest Q-value of its table,
+// or a random move with the probability of its exploration schedule.
+// At the end of each game it updates the values of the moves it played by Q-learning:
+// the last move of a side towards the result of the game and every other move towards the discounted
+// value of the best move of the side's next position. The same player may play both sides of a game.
+type Player struct {
+	t       *Table
+	name    string
+	cfg     Config
+	rnd     *rand.Rand
+	games   int
+	episode episode.Episode
+}
+
+// NewPlayer returns a new learning player that learns into t.
+func NewPlayer(t *Table, name string, cfg Config) (*Player, error) {
+	if t == nil || cfg.Alpha < 0 || cfg.Alpha > 1 || cfg.Gamma < 0 || cfg.Gamma > 1 {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Player{t: t, name: name, cfg: cfg, rnd: rand.New(rand.NewSource(cfg.Seed))}, nil
+}
+
+// Games returns the number of games the player has finished.
+func (p *Player) Games() int {
+	return p.games
+}
+
+// Table returns the player's table.
+func (p *Player) Table() *Table {
+	return p.t
+}
+
+// Play returns the best known move or, while exploring, a random move.
+func (p *Player) Play(board [][]int, side int) (int, int) {
+	moves := p.t.e.LegalMoves(board)
+	if len(moves) == 0 || side < 1 || side > 2 {
+		return -1, -1
+	}
+	k, indices := p.t.indices(board, moves)
+	m := p.rnd.Intn(len(moves))
+	if p.rnd.Float64() >= p.cfg.Exploration.Epsilon(p.games) {
+		best, ties := 0.0, 0
+		for n, index := range indices {
+			v := p.t.values[k][index]
+			switch {
+			case ties == 0 || v > best:
+				m, best, ties = n, v, 1
+			case v == best:
+				// break ties at random so that equally good moves are all explored
+				ties++
+				if p.rnd.Intn(ties) == 0 {
+					m = n
+				}
+			}
+		}
+	}
+	p.episode.Play(side, episode.Step{Key: k, Move: indices[m]}, p.cfg.Learn)
+	return moves[m][0], moves[m][1]
+}
+
+// Done updates the table with the moves of the finished game.
+// When the player plays both sides Done is called twice per game, the second call does nothing.
+func (p *Player) Done(winner int) {
+	steps, ok := p.episode.End()
+	if !ok {
+		return
+	}
+	p.games++
+	for side := 1; side <= 2; side++ {
+		played := steps[side]
+		if len(played) == 0 {
+			continue
+		}
+		target := 0.0
+		switch winner {
+		case 0:
+		case side:
+			target = 1
+		default:
+			target = -1
+		}
+		for k := len(played) - 1; k >= 0; k-- {
+			if k < len(played)-1 {
+				target = p.cfg.Gamma * p.t.max(played[k+1].Key)
+			}
+			p.t.update(played[k].Key, played[k].Move, target, p.cfg.Alpha)
+		}
+	}
+}
+
+// Name returns the player name.
+func (p *Player) Name() string {
+	return p.name
+}
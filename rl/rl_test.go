@@ -0,0 +1,109 @@
+package rl
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func TestSchedule_Epsilon(t *testing.T) {
+	s := Schedule{Start: 1, End: 0.2, Games: 4}
+	tt := []struct {
+		games int
+		want  float64
+	}{
+		{0, 1},
+		{2, 0.6},
+		{4, 0.2},
+		{100, 0.2},
+	}
+	for _, tc := range tt {
+		if got := s.Epsilon(tc.games); got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("Schedule.Epsilon(%v) = %v, want %v", tc.games, got, tc.want)
+		}
+	}
+	if got := (Schedule{Start: 0.5, End: 0.5}).Epsilon(0); got != 0.5 {
+		t.Errorf("Schedule.Epsilon(0) = %v, want 0.5", got)
+	}
+}
+
+// play plays a game between p1 and p2 and returns the winner.
+func play(t *testing.T, e *game.Engine, p1, p2 *Player) int {
+	g, err := game.New(e, p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g.Play() {
+	}
+	_, winner := g.Result()
+	return winner
+}
+
+func TestPlayer_Learn(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	table, _ := NewTable(e)
+	cfg := DefaultConfig()
+	cfg.Seed = 1
+	learner, err := NewPlayer(table, "learner", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := 0; k < 30000; k++ {
+		play(t, e, learner, learner)
+	}
+	if learner.Games() != 30000 {
+		t.Errorf("Player.Games() = %v, want 30000", learner.Games())
+	}
+
+	greedy, _ := NewPlayer(table, "greedy", Config{})
+	if i, j := greedy.Play([][]int{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}}, 1); i != 0 || j != 2 {
+		t.Errorf("Player.Play() = (%v, %v), want (0, 2)", i, j)
+	}
+	empty, _ := NewTable(e)
+	random, _ := NewPlayer(empty, "random", Config{Exploration: Schedule{Start: 1, End: 1}, Seed: 2})
+	for k := 0; k < 200; k++ {
+		side := 1 + k%2
+		var winner int
+		if side == 1 {
+			winner = play(t, e, greedy, random)
+		} else {
+			winner = play(t, e, random, greedy)
+		}
+		if winner != 0 && winner != side {
+			t.Fatalf("learned player lost game %v as side %v to a random player", k, side)
+		}
+	}
+	if empty.Len() != 0 {
+		t.Errorf("table of a player that does not learn has %v positions", empty.Len())
+	}
+}
+
+func TestTable_ReadWrite(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	table, _ := NewTable(e)
+	p, _ := NewPlayer(table, "learner", DefaultConfig())
+	for k := 0; k < 100; k++ {
+		play(t, e, p, p)
+	}
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(bytes.NewReader(buf.Bytes()), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.values, table.values) {
+		t.Error("Read() table differs from the written table")
+	}
+	if v := got.Value([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 2, 2); v != table.Value([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 0, 0) {
+		t.Errorf("symmetric moves have different values")
+	}
+
+	other, _ := game.NewEngine(4, 4, 3)
+	if _, err := Read(bytes.NewReader(buf.Bytes()), other); err != ErrInvalidTable {
+		t.Errorf("Read() error = %v, want %v", err, ErrInvalidTable)
+	}
+}
@@ -0,0 +1,135 @@
+// Package rl provides a tabular reinforcement learning player that learns to play by
+// temporal difference updates over the games it plays.
+// Values are stored per position in its canonical form under the board's symmetries, so
+// what is learned in one corner of the board also applies to the other corners.
+package rl
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// ErrInvalidTable is returned when a table can not be read or does not match an engine.
+var ErrInvalidTable = errors.New("invalid table")
+
+// Table holds the Q-values of the positions of an engine's board: the expected result of playing a move,
+// 1 for a win, 0 for a draw and -1 for a loss, from the point of view of the side that plays it.
+// Moves without a value have the value 0.
+type Table struct {
+	e      *game.Engine
+	values map[string]map[int]float64 // canonical board -> move index in the canonical board -> value
+}
+
+// NewTable returns a new empty table for the engine's board.
+func NewTable(e *game.Engine) (*Table, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	return &Table{e: e, values: make(map[string]map[int]float64)}, nil
+}
+
+// Len returns the number of positions in the table.
+func (t *Table) Len() int {
+	return len(t.values)
+}
+
+// Value returns the value of playing i, j on board.
+func (t *Table) Value(board [][]int, i, j int) float64 {
+	k, m := t.index(board, i, j)
+	return t.values[k][m]
+}
+
+// index returns the key of board and the index of i, j in the canonical board.
+func (t *Table) index(board [][]int, i, j int) (string, int) {
+	canonical, ci, cj := game.CanonicalMove(board, i, j)
+	return game.Key(canonical), ci*t.e.Columns() + cj
+}
+
+// indices returns the key of board and the indices of moves in the canonical board.
+func (t *Table) indices(board [][]int, moves [][]int) (string, []int) {
+	canonical, mapped := game.CanonicalMoves(board, moves)
+	indices := make([]int, len(mapped))
+	for n, m := range mapped {
+		indices[n] = m[0]*t.e.Columns() + m[1]
+	}
+	return game.Key(canonical), indices
+}
+
+// update moves the value of move m of position k towards target by the learning rate alpha.
+func (t *Table) update(k string, m int, target, alpha float64) {
+	moves, ok := t.values[k]
+	if !ok {
+		moves = make(map[int]float64)
+		t.values[k] = moves
+	}
+	moves[m] += alpha * (target - moves[m])
+}
+
+// max returns the highest value of the moves of position k.
+func (t *Table) max(k string) float64 {
+	moves := t.values[k]
+	best, empty := 0.0, 0
+	for _, c := range k {
+		if c == '0' {
+			empty++
+		}
+	}
+	if len(moves) == empty && empty > 0 {
+		// every move has a value, the implicit 0 of the unvisited moves does not count
+		best = -1
+	}
+	for _, v := range moves {
+		if v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+type jsonTable struct {
+	Rows    int                        `json:"rows"`
+	Columns int                        `json:"columns"`
+	Target  int                        `json:"target"`
+	Values  map[string]map[int]float64 `json:"values"`
+}
+
+// Write writes the table to w in JSON format.
+// Positions are keyed by the game.Key of their canonical board and moves by their row major index.
+func (t *Table) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(jsonTable{
+		Rows:    t.e.Rows(),
+		Columns: t.e.Columns(),
+		Target:  t.e.Target(),
+		Values:  t.values,
+	})
+}
+
+// Read reads a table written by Write for the engine's board.
+func Read(r io.Reader, e *game.Engine) (*Table, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	var jt jsonTable
+	if err := json.NewDecoder(r).Decode(&jt); err != nil {
+		return nil, err
+	}
+	if jt.Rows != e.Rows() || jt.Columns != e.Columns() || jt.Target != e.Target() {
+		return nil, ErrInvalidTable
+	}
+	t, _ := NewTable(e)
+	for k, moves := range jt.Values {
+		if len(k) != e.Rows()*e.Columns() {
+			return nil, ErrInvalidTable
+		}
+		for m := range moves {
+			if m < 0 || m >= len(k) || k[m] != '0' {
+				return nil, ErrInvalidTable
+			}
+		}
+		t.values[k] = moves
+	}
+	return t, nil
+}
@@ -0,0 +1,460 @@
+// Package tablebase generates endgame tablebases for small boards by retrograde analysis.
+// A tablebase holds the game theoretic value of every position reachable from the empty board with
+// up to a given number of empty positions, with the number of moves to the end of the game under
+// optimal play, and can be used to verify players and the game engine itself.
+//
+// Positions are stored once per symmetry class, keyed by the base 3 number of their canonical board,
+// so boards are limited to 25 positions. Generation time and memory grow quickly with the number of
+// empty positions: 3x3 and 4x4 boards are fine down to the empty board, 5x5 boards only for endgames
+// with a few empty positions.
+package tablebase
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+// MaxPositions is the largest number of board positions a tablebase supports.
+const MaxPositions = 25
+
+// ErrTooLarge is returned when a board has more than MaxPositions positions.
+var ErrTooLarge = errors.New("tablebase too large")
+
+// ErrNotFound is returned when a position is not reachable from the empty board or has more empty
+// positions than the tablebase holds.
+var ErrNotFound = errors.New("position not found")
+
+// ErrInvalidTable is returned when a tablebase can not be read or does not match an engine.
+var ErrInvalidTable = errors.New("invalid tablebase")
+
+// magic starts every tablebase file.
+var magic = [4]byte{'T', 'T', 'T', 'T'}
+
+// Value is the game theoretic value of a position for the side to move.
+type Value int
+
+const (
+	// Loss means the side to move loses against optimal play.
+	Loss Value = -1
+	// Draw means the game is a draw with optimal play.
+	Draw Value = 0
+	// Win means the side to move wins with optimal play.
+	Win Value = 1
+)
+
+// Entry is the value of a position and its distance to the end of the game in moves of both sides.
+// The winning side plays for the shortest win and the losing side for the longest loss.
+type Entry struct {
+	Value    Value
+	Distance int
+}
+
+func pack(en Entry) byte {
+	return byte(en.Value+1)<<6 | byte(en.Distance)
+}
+
+func unpack(b byte) Entry {
+	return Entry{Value: Value(b>>6) - 1, Distance: int(b & 0x3f)}
+}
+
+// Table is an endgame tablebase for an engine's board.
+type Table struct {
+	e       *game.Engine
+	n       int
+	weights [][]uint64 // weights[s][p] is the base 3 weight of position p after symmetry s
+	windows [][][]int  // windows[p] are the cells of every winning window containing position p
+	entries map[uint64]byte
+}
+
+// Generate generates the tablebase of the engine's board for the positions with at most empty empty
+// positions, 0 means every position down to the empty board. The positions are generated backward,
+// starting from the full boards, and each number of empty positions only depends on the one below it.
+// ErrTooLarge is returned if the board has more than MaxPositions positions.
+func Generate(e *game.Engine, empty int) (*Table, error) {
+	t, err := newTable(e)
+	if err != nil {
+		return nil, err
+	}
+	if empty <= 0 || empty > t.n {
+		empty = t.n
+	}
+	t.entries = make(map[uint64]byte)
+	for k := 0; k <= empty; k++ {
+		side := 1 + (t.n-k)%2
+		for _, key := range t.positions(k) {
+			if k == 0 {
+				// a full board without a line is a draw
+				t.entries[key] = pack(Entry{Value: Draw})
+				continue
+			}
+			board := t.decode(key)
+			keys := t.keys(board)
+			best := Entry{Value: Loss - 1}
+			for p, v := range board {
+				if v != 0 {
+					continue
+				}
+				child := t.child(keys, p, side)
+				board[p] = side
+				if t.wins(board, p, side) {
+					// the game is over, the opponent to move has lost
+					t.entries[child] = pack(Entry{Value: Loss})
+				}
+				board[p] = 0
+				en := unpack(t.entries[child])
+				en = Entry{Value: -en.Value, Distance: en.Distance + 1}
+				if better(en, best) {
+					best = en
+				}
+			}
+			t.entries[key] = pack(best)
+		}
+	}
+	return t, nil
+}
+
+// positions returns the canonical keys of the boards with empty empty positions, as many X's as O's
+// or one more and no line of either side, which are the positions reachable from the empty board
+// where the game is not over.
+func (t *Table) positions(empty int) []uint64 {
+	stones := t.n - empty
+	left := [3]int{empty, (stones + 1) / 2, stones / 2}
+	found := make(map[uint64]bool)
+	board := make([]int, t.n)
+	keys := make([]uint64, len(t.weights)) // keys of board under every symmetry, updated as positions are placed
+	var place func(p int)
+	place = func(p int) {
+		if p == t.n {
+			best := keys[0]
+			for _, k := range keys[1:] {
+				if k < best {
+					best = k
+				}
+			}
+			found[best] = true
+			return
+		}
+		for v := 0; v <= 2; v++ {
+			if left[v] == 0 {
+				continue
+			}
+			board[p] = v
+			// positions after p are still empty, so a line through p is complete when placing p
+			if v != 0 && t.wins(board, p, v) {
+				continue
+			}
+			for s, w := range t.weights {
+				keys[s] += uint64(v) * w[p]
+			}
+			left[v]--
+			place(p + 1)
+			left[v]++
+			for s, w := range t.weights {
+				keys[s] -= uint64(v) * w[p]
+			}
+		}
+		board[p] = 0
+	}
+	place(0)
+	list := make([]uint64, 0, len(found))
+	for k := range found {
+		list = append(list, k)
+	}
+	return list
+}
+
+// better returns whether a is a better result than b for the side to move.
+func better(a, b Entry) bool {
+	if a.Value != b.Value {
+		return a.Value > b.Value
+	}
+	if a.Value == Win {
+		return a.Distance < b.Distance
+	}
+	return a.Distance > b.Distance
+}
+
+func newTable(e *game.Engine) (*Table, error) {
+	if e == nil {
+		return nil, game.ErrInvalidGameSpecs
+	}
+	rows, columns, target := e.Rows(), e.Columns(), e.Target()
+	n := rows * columns
+	if n > MaxPositions {
+		return nil, ErrTooLarge
+	}
+	pow3 := make([]uint64, n)
+	pow3[n-1] = 1
+	for p := n - 2; p >= 0; p-- {
+		pow3[p] = pow3[p+1] * 3
+	}
+	t := &Table{e: e, n: n}
+	for _, s := range game.Symmetries(rows, columns) {
+		w := make([]uint64, n)
+		for p := range w {
+			i, j := s.Apply(rows, columns, p/columns, p%columns)
+			w[p] = pow3[i*columns+j]
+		}
+		t.weights = append(t.weights, w)
+	}
+	t.windows = make([][][]int, n)
+	for _, d := range game.Directions() {
+		for i := 0; i < rows; i++ {
+			for j := 0; j < columns; j++ {
+				ei, ej := i+(target-1)*d[0], j+(target-1)*d[1]
+				if ei < 0 || ej < 0 || ei >= rows || ej >= columns {
+					continue
+				}
+				window := make([]int, target)
+				for k := range window {
+					window[k] = (i+k*d[0])*columns + j + k*d[1]
+				}
+				for _, p := range window {
+					t.windows[p] = append(t.windows[p], window)
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+// keys returns the key of board under every symmetry.
+func (t *Table) keys(board []int) []uint64 {
+	keys := make([]uint64, len(t.weights))
+	for s, w := range t.weights {
+		for p, v := range board {
+			keys[s] += uint64(v) * w[p]
+		}
+	}
+	return keys
+}
+
+// child returns the canonical key of the board with keys after side plays position p.
+func (t *Table) child(keys []uint64, p, side int) uint64 {
+	best := keys[0] + uint64(side)*t.weights[0][p]
+	for s := 1; s < len(keys); s++ {
+		if k := keys[s] + uint64(side)*t.weights[s][p]; k < best {
+			best = k
+		}
+	}
+	return best
+}
+
+func (t *Table) canonical(board []int) uint64 {
+	keys := t.keys(board)
+	best := keys[0]
+	for _, k := range keys[1:] {
+		if k < best {
+			best = k
+		}
+	}
+	return best
+}
+
+func (t *Table) decode(key uint64) []int {
+	board := make([]int, t.n)
+	for p := t.n - 1; p >= 0; p-- {
+		board[p] = int(key % 3)
+		key /= 3
+	}
+	return board
+}
+
+// wins returns whether side's symbol at position p completes a window.
+func (t *Table) wins(board []int, p, side int) bool {
+	for _, window := range t.windows[p] {
+		complete := true
+		for _, q := range window {
+			if board[q] != side {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return true
+		}
+	}
+	return false
+}
+
+// won returns whether side won the game on board with its last move: side has a line, the opponent
+// has none, and one of side's symbols can be taken back to leave a position without a line.
+func (t *Table) won(board []int, side int) bool {
+	if !t.hasLine(board, side) || t.hasLine(board, 3-side) {
+		return false
+	}
+	for p, v := range board {
+		if v != side {
+			continue
+		}
+		board[p] = 0
+		before := t.hasLine(board, side)
+		board[p] = side
+		if !before {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLine returns whether side has a complete window on board.
+func (t *Table) hasLine(board []int, side int) bool {
+	for p, v := range board {
+		if v == side && t.wins(board, p, side) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of positions in the tablebase.
+func (t *Table) Len() int {
+	return len(t.entries)
+}
+
+// Lookup returns the entry of board with side to move.
+// Positions where the game is over are reachable too, their distance is 0 and the side to move has lost
+// or drawn. Won positions are recognized from the board, so they are found even with as many empty
+// positions as the limit of the tablebase, which Generate only stores below it. ErrInvalidBoard and ErrInvalidSide are returned for boards that do not fit the engine or
+// do not have side to move, and ErrNotFound for boards that can not be reached by a game or have more
+// empty positions than the tablebase was generated for.
+func (t *Table) Lookup(board [][]int, side int) (Entry, error) {
+	flat, err := t.flatten(board, side)
+	if err != nil {
+		return Entry{}, err
+	}
+	b, ok := t.entries[t.canonical(flat)]
+	if !ok {
+		if t.won(flat, 3-side) {
+			return Entry{Value: Loss}, nil
+		}
+		return Entry{}, ErrNotFound
+	}
+	return unpack(b), nil
+}
+
+// Optimal returns whether side playing i, j on board keeps the value of the position.
+// It can be used to verify that a player never throws away a win or a draw.
+func (t *Table) Optimal(board [][]int, side, i, j int) (bool, error) {
+	en, err := t.Lookup(board, side)
+	if err != nil {
+		return false, err
+	}
+	if !t.e.Legal(board, i, j) {
+		return false, nil
+	}
+	flat, _ := t.flatten(board, side)
+	p := i*t.e.Columns() + j
+	flat[p] = side
+	if t.wins(flat, p, side) {
+		return true, nil
+	}
+	b, ok := t.entries[t.canonical(flat)]
+	if !ok {
+		return false, ErrNotFound
+	}
+	return -unpack(b).Value == en.Value, nil
+}
+
+func (t *Table) flatten(board [][]int, side int) ([]int, error) {
+	if len(board) != t.e.Rows() {
+		return nil, game.ErrInvalidBoard
+	}
+	flat := make([]int, 0, t.n)
+	x, o := 0, 0
+	for _, row := range board {
+		if len(row) != t.e.Columns() {
+			return nil, game.ErrInvalidBoard
+		}
+		for _, v := range row {
+			switch v {
+			case 1:
+				x++
+			case 2:
+				o++
+			case 0:
+			default:
+				return nil, game.ErrInvalidBoard
+			}
+			flat = append(flat, v)
+		}
+	}
+	if (side == 1 && x != o) || (side == 2 && x != o+1) || (side != 1 && side != 2) {
+		return nil, game.ErrInvalidSide
+	}
+	return flat, nil
+}
+
+// Write writes the tablebase to w: the magic "TTTT", rows, columns and target as little endian uint16
+// and the number of positions as uint64, followed by the positions in key order, each as the uvarint
+// difference to the previous key and one byte holding the value + 1 in the upper 2 bits and the distance
+// in the lower 6 bits.
+func (t *Table) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header := []interface{}{
+		magic,
+		uint16(t.e.Rows()), uint16(t.e.Columns()), uint16(t.e.Target()),
+		uint64(len(t.entries)),
+	}
+	for _, v := range header {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	keys := make([]uint64, 0, len(t.entries))
+	for k := range t.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a] < keys[b] })
+	buf := make([]byte, binary.MaxVarintLen64+1)
+	prev := uint64(0)
+	for _, k := range keys {
+		n := binary.PutUvarint(buf, k-prev)
+		buf[n] = t.entries[k]
+		if _, err := bw.Write(buf[:n+1]); err != nil {
+			return err
+		}
+		prev = k
+	}
+	return bw.Flush()
+}
+
+// Read reads a tablebase written by Write for the engine's board.
+func Read(r io.Reader, e *game.Engine) (*Table, error) {
+	t, err := newTable(e)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(r)
+	var header struct {
+		Magic                 [4]byte
+		Rows, Columns, Target uint16
+		Positions             uint64
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.Magic != magic || int(header.Rows) != e.Rows() || int(header.Columns) != e.Columns() || int(header.Target) != e.Target() {
+		return nil, ErrInvalidTable
+	}
+	t.entries = make(map[uint64]byte, header.Positions)
+	key := uint64(0)
+	for p := uint64(0); p < header.Positions; p++ {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		key += delta
+		t.entries[key] = b
+	}
+	return t, nil
+}
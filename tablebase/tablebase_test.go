@@ -0,0 +1,216 @@
+package tablebase
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mraufc/tictactoe/game"
+)
+
+func TestGenerate(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	tb, err := Generate(e, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the number of 3x3 positions reachable from the empty board, up to symmetry
+	if tb.Len() != 765 {
+		t.Errorf("Generate() Len() = %v, want 765", tb.Len())
+	}
+	// a tablebase limited to 3 empty positions holds the same entries for the positions it has
+	limited, err := Generate(e, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limited.Len() == 0 || limited.Len() >= tb.Len() {
+		t.Errorf("Generate() with limit Len() = %v, want between 0 and %v", limited.Len(), tb.Len())
+	}
+	for k, b := range limited.entries {
+		if tb.entries[k] != b {
+			t.Errorf("Generate() with limit entry %v = %v, want %v", k, unpack(b), unpack(tb.entries[k]))
+		}
+	}
+	if _, err := limited.Lookup([][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, 1); err != ErrNotFound {
+		t.Errorf("Table.Lookup() beyond the limit error = %v, want %v", err, ErrNotFound)
+	}
+	// a won position with as many empty positions as the limit is only reached from above the limit
+	boundary, _ := Generate(e, 4)
+	if en, err := boundary.Lookup([][]int{{1, 1, 1}, {2, 2, 0}, {0, 0, 0}}, 2); err != nil || en != (Entry{Value: Loss}) {
+		t.Errorf("Table.Lookup() of a won position at the limit = %v, %v, want %v, nil", en, err, Entry{Value: Loss})
+	}
+	if _, err := boundary.Lookup([][]int{{1, 1, 1}, {2, 2, 2}, {1, 0, 0}}, 2); err != ErrNotFound {
+		t.Errorf("Table.Lookup() of an unreachable position error = %v, want %v", err, ErrNotFound)
+	}
+	big, _ := game.NewEngine(6, 6, 4)
+	if _, err := Generate(big, 0); err != ErrTooLarge {
+		t.Errorf("Generate() for 6x6 error = %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestGenerate_Endgame(t *testing.T) {
+	e, _ := game.NewEngine(5, 5, 3)
+	tb, err := Generate(e, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tb.Len() == 0 {
+		t.Fatalf("Generate() Len() = 0")
+	}
+	tests := []struct {
+		name    string
+		board   [][]int
+		want    Entry
+		wantErr error
+	}{
+		{
+			name:  "O wins in one",
+			board: [][]int{{1, 1, 2, 2, 1}, {1, 2, 2, 0, 1}, {0, 1, 1, 2, 2}, {1, 2, 2, 1, 1}, {2, 1, 2, 1, 2}},
+			want:  Entry{Value: Win, Distance: 1},
+		},
+		{
+			name:    "too many empty positions",
+			board:   [][]int{{0, 1, 0, 2, 1}, {1, 2, 2, 0, 1}, {0, 1, 1, 2, 2}, {1, 2, 2, 1, 1}, {2, 1, 2, 1, 2}},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tb.Lookup(tt.board, 2)
+			if err != tt.wantErr {
+				t.Fatalf("Table.Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Lookup(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	tb, err := Generate(e, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		board   [][]int
+		side    int
+		want    Entry
+		wantErr error
+	}{
+		{
+			name:  "empty board is a draw",
+			board: [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			side:  1,
+			want:  Entry{Value: Draw, Distance: 9},
+		},
+		{
+			name:  "O on an edge against the center loses",
+			board: [][]int{{0, 2, 0}, {0, 1, 0}, {0, 0, 0}},
+			side:  1,
+			want:  Entry{Value: Win, Distance: 5},
+		},
+		{
+			name:  "X wins in one",
+			board: [][]int{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}},
+			side:  1,
+			want:  Entry{Value: Win, Distance: 1},
+		},
+		{
+			name:  "game is over",
+			board: [][]int{{1, 1, 1}, {2, 2, 0}, {0, 0, 0}},
+			side:  2,
+			want:  Entry{Value: Loss, Distance: 0},
+		},
+		{
+			name:    "unreachable",
+			board:   [][]int{{1, 1, 1}, {2, 2, 2}, {1, 0, 0}},
+			side:    2,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "wrong side to move",
+			board:   [][]int{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			side:    1,
+			wantErr: game.ErrInvalidSide,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tb.Lookup(tt.board, tt.side)
+			if err != tt.wantErr {
+				t.Fatalf("Table.Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	center := [][]int{{0, 0, 0}, {0, 1, 0}, {0, 0, 0}}
+	if ok, _ := tb.Optimal(center, 2, 0, 1); ok {
+		t.Errorf("Table.Optimal() for an edge = true, want false")
+	}
+	if ok, _ := tb.Optimal(center, 2, 0, 0); !ok {
+		t.Errorf("Table.Optimal() for a corner = false, want true")
+	}
+}
+
+// TestTable_Engine verifies the engine's game over detection against the tablebase on random games.
+func TestTable_Engine(t *testing.T) {
+	e, _ := game.NewEngine(4, 4, 3)
+	tb, err := Generate(e, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for g := 0; g < 200; g++ {
+		s, _ := game.NewState(e, nil)
+		for {
+			moves := s.LegalMoves()
+			m := moves[rnd.Intn(len(moves))]
+			if err := s.Do(m[0], m[1]); err != nil {
+				t.Fatal(err)
+			}
+			en, err := tb.Lookup(s.Board(), s.Side())
+			if err != nil {
+				t.Fatalf("Table.Lookup() error = %v for %v", err, s.Board())
+			}
+			inProgress, winner := s.Result()
+			if inProgress != (en.Distance > 0) {
+				t.Fatalf("engine in progress = %v, tablebase distance = %v for %v", inProgress, en.Distance, s.Board())
+			}
+			if !inProgress {
+				if (winner == 0) != (en.Value == Draw) {
+					t.Fatalf("engine winner = %v, tablebase value = %v for %v", winner, en.Value, s.Board())
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestTable_ReadWrite(t *testing.T) {
+	e, _ := game.NewEngine(3, 3, 3)
+	tb, _ := Generate(e, 0)
+	var buf bytes.Buffer
+	if err := tb.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(&buf, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, tb) {
+		t.Errorf("Read() did not return the written tablebase")
+	}
+	other, _ := game.NewEngine(3, 4, 3)
+	buf.Reset()
+	tb.Write(&buf)
+	if _, err := Read(&buf, other); err != ErrInvalidTable {
+		t.Errorf("Read() for another engine error = %v, want %v", err, ErrInvalidTable)
+	}
+}